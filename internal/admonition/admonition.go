@@ -0,0 +1,78 @@
+package admonition
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindAdmonition 提示块节点
+var KindAdmonition = ast.NewNodeKind("Admonition")
+
+// Admonition 提示块，由 > [!NOTE] 引用或 :::note 容器生成，子节点为提示内容
+type Admonition struct {
+	ast.BaseBlock
+	AdmonitionType string // 类型，统一为小写，如 note、tip、warning
+	Title          string // 标题，未指定时使用类型对应的默认标题
+	Collapsible    bool   // 是否可折叠
+	Open           bool   // 可折叠时是否默认展开
+
+	fence int // ::: 容器的冒号数量
+}
+
+// Kind 实现 ast.Node
+func (n *Admonition) Kind() ast.NodeKind { return KindAdmonition }
+
+// Dump 实现 ast.Node
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Type": n.AdmonitionType, "Title": n.Title}, nil)
+}
+
+// 各类型的默认标题
+var titles = map[string]string{
+	"note":      "备注",
+	"info":      "信息",
+	"tip":       "提示",
+	"success":   "成功",
+	"important": "重要",
+	"warning":   "警告",
+	"caution":   "注意",
+	"danger":    "危险",
+	"example":   "示例",
+	"question":  "问题",
+}
+
+// newAdmonition 根据类型、折叠标记（+ 展开，- 收起）和标题创建提示块
+func newAdmonition(kind, fold, title string) *Admonition {
+	kind = strings.ToLower(kind)
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = titles[kind]
+	}
+	if title == "" {
+		title = strings.ToUpper(kind[:1]) + kind[1:]
+	}
+	return &Admonition{
+		AdmonitionType: kind,
+		Title:          title,
+		Collapsible:    fold != "",
+		Open:           fold == "+",
+	}
+}
+
+type extension struct{}
+
+// Extension 提示块扩展，支持 GitHub 风格的 > [!NOTE] 和 :::warning 容器，
+// 节点由 markup 渲染钩子的 render-admonition 模板输出
+var Extension = &extension{}
+
+// Extend 实现 goldmark.Extender
+func (e *extension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&containerParser{}, 710)),
+		parser.WithASTTransformers(util.Prioritized(&alertTransformer{}, 100)),
+	)
+}
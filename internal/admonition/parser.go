@@ -0,0 +1,140 @@
+package admonition
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/jiangjiax/stars/internal/mdutil"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// :::type[+-] 标题
+var containerPattern = regexp.MustCompile(`^(:{3,})\s*([A-Za-z][A-Za-z0-9_-]*)([+-]?)(?:\s+(.*))?$`)
+
+// [!TYPE][+-] 标题
+var alertPattern = regexp.MustCompile(`^\[!([A-Za-z][A-Za-z0-9_-]*)\]([+-]?)(?:\s+(.*))?$`)
+
+// containerParser 解析 :::warning ... ::: 容器，内容按 markdown 解析
+//
+// 嵌套时外层使用更多的冒号，结束行的冒号数量需与开始行一致
+type containerParser struct{}
+
+func (p *containerParser) Trigger() []byte {
+	return []byte{':'}
+}
+
+func (p *containerParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 {
+		return nil, parser.NoChildren
+	}
+
+	m := containerPattern.FindSubmatch(bytes.TrimSpace(line[pos:]))
+	if m == nil {
+		return nil, parser.NoChildren
+	}
+
+	node := newAdmonition(string(m[2]), string(m[3]), string(m[4]))
+	node.fence = len(m[1])
+	mdutil.SkipLine(reader)
+	return node, parser.HasChildren
+}
+
+func (p *containerParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	n := node.(*Admonition)
+	line, _ := reader.PeekLine()
+	if line == nil {
+		return parser.Close
+	}
+
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == n.fence && len(bytes.Trim(trimmed, ":")) == 0 {
+		mdutil.SkipLine(reader)
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+func (p *containerParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *containerParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *containerParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// alertTransformer 将首行为 [!TYPE] 的引用块转换为提示块
+type alertTransformer struct{}
+
+func (t *alertTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if q, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, q)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, q := range quotes {
+		p, ok := q.FirstChild().(*ast.Paragraph)
+		if !ok || p.Lines().Len() == 0 {
+			continue
+		}
+		first := p.Lines().At(0)
+		m := alertPattern.FindSubmatch(bytes.TrimSpace(first.Value(source)))
+		if m == nil {
+			continue
+		}
+
+		removeFirstLine(p, first)
+		if p.ChildCount() == 0 {
+			q.RemoveChild(q, p)
+		}
+
+		node := newAdmonition(string(m[1]), string(m[2]), string(m[3]))
+		for c := q.FirstChild(); c != nil; {
+			next := c.NextSibling()
+			node.AppendChild(node, c)
+			c = next
+		}
+		q.Parent().ReplaceChild(q.Parent(), q, node)
+	}
+}
+
+// removeFirstLine 删除段落首行（提示块标记所在行）的内容
+func removeFirstLine(p *ast.Paragraph, first text.Segment) {
+	for c := p.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		if textStart(c) >= first.Stop {
+			break
+		}
+		p.RemoveChild(p, c)
+		c = next
+	}
+
+	lines := text.NewSegments()
+	for i := 1; i < p.Lines().Len(); i++ {
+		lines.Append(p.Lines().At(i))
+	}
+	p.SetLines(lines)
+}
+
+// textStart 返回节点中第一段文本在源文件中的位置，没有文本时返回 -1
+func textStart(n ast.Node) int {
+	start := -1
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if t, ok := c.(*ast.Text); ok && entering {
+			start = t.Segment.Start
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return start
+}
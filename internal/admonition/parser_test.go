@@ -0,0 +1,44 @@
+package admonition
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestContainerAtEOF(t *testing.T) {
+	// 结束的 ::: 位于最后一行且没有换行符
+	source := []byte(":::warning\nbody\n:::")
+	md := goldmark.New(goldmark.WithExtensions(Extension))
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	node, ok := doc.FirstChild().(*Admonition)
+	if !ok || node.NextSibling() != nil {
+		t.Fatalf("expected a single admonition, got:\n%s", dump(doc, source))
+	}
+	if node.AdmonitionType != "warning" {
+		t.Errorf("AdmonitionType = %q, want %q", node.AdmonitionType, "warning")
+	}
+	if node.ChildCount() != 1 || string(node.FirstChild().Text(source)) != "body" {
+		t.Errorf("expected the body paragraph only, got:\n%s", dump(doc, source))
+	}
+}
+
+// dump 节点树的文本，用于失败信息
+func dump(n ast.Node, source []byte) string {
+	var out []byte
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			out = append(out, c.Kind().String()...)
+			if t, ok := c.(*ast.Text); ok {
+				out = append(out, ' ')
+				out = append(out, t.Segment.Value(source)...)
+			}
+			out = append(out, '\n')
+		}
+		return ast.WalkContinue, nil
+	})
+	return string(out)
+}
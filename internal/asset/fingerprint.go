@@ -0,0 +1,226 @@
+package asset
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Asset 加上指纹的静态文件
+type Asset struct {
+	Path      string // 相对于 static 目录的带哈希路径，如 images/avatar.1a2b3c4d5e6f7a8b.jpg
+	Integrity string // 子资源完整性（SRI）哈希
+	Content   []byte // 文件内容，CSS 中引用的静态文件已替换为带哈希的路径
+	hash      string
+}
+
+var (
+	// srcPattern 匹配 HTML 中 src、href 属性的值
+	srcPattern = regexp.MustCompile(`(?i)\b(?:src|href)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	// srcsetPattern 匹配 HTML 中 srcset 属性的值
+	srcsetPattern = regexp.MustCompile(`(?i)\bsrcset\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	// cssURLPattern 匹配 CSS 和 style 属性中 url() 的地址
+	cssURLPattern = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^'")\s]*))\s*\)`)
+	// candidatePattern 匹配 srcset 中每个候选图片的地址
+	candidatePattern = regexp.MustCompile(`(?:^|,)\s*([^\s,]+)`)
+	// codePattern 匹配 <pre> 和 <code> 元素，其中的文本不替换
+	codePattern = regexp.MustCompile(`(?is)<pre\b.*?</pre>|<code\b.*?</code>`)
+	// staticRefPattern 匹配站内的静态文件地址，如 /static/images/a.png、../static/css/b.css
+	staticRefPattern = regexp.MustCompile(`^(?:/|\./|(?:\.\./)+)static/([^?#]+)`)
+)
+
+// Fingerprint 为静态目录中的所有文件计算带内容哈希的路径。
+// 后面的目录覆盖前面目录中的同名文件，与复制静态文件的顺序一致；dist 目录已由资源管道处理，
+// 隐藏文件和目录（如 .DS_Store）不发布
+func (p *Pipeline) Fingerprint(dirs ...string) error {
+	files := make(map[string][]byte)
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && file == dir {
+					return nil
+				}
+				return err
+			}
+			hidden := file != dir && strings.HasPrefix(info.Name(), ".")
+			if info.IsDir() {
+				if hidden {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if hidden {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if isDist(rel) {
+				return nil
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			files[rel] = content
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fingerprint static files: %w", err)
+		}
+	}
+
+	p.FingerprintFiles(files)
+	return nil
+}
+
+// FingerprintFiles 为文件计算带哈希的路径，键为相对于 static 目录的路径。
+// CSS 文件在其他文件之后处理，以便先替换其中引用的静态文件
+func (p *Pipeline) FingerprintFiles(files map[string][]byte) map[string]*Asset {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sort.SliceStable(names, func(i, j int) bool {
+		return path.Ext(names[i]) != ".css" && path.Ext(names[j]) == ".css"
+	})
+
+	result := make(map[string]*Asset, len(files))
+	for _, name := range names {
+		content := files[name]
+		if path.Ext(name) == ".css" {
+			content = p.RewriteURLs(content)
+		}
+
+		ext := path.Ext(name)
+		hash := contentHash(content)
+		asset := &Asset{
+			Path:      fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), hash, ext),
+			Integrity: integrity(content),
+			Content:   content,
+			hash:      hash,
+		}
+		p.assets[name] = asset
+		result[name] = asset
+	}
+	return result
+}
+
+// RewriteURLs 将 HTML 和 CSS 中引用的静态文件替换为带哈希的路径。
+// 只替换 src、href、srcset 属性和 url() 中以 /static/、./static/ 或 ../static/ 开头的地址，
+// 其他站点的绝对地址和 <pre>、<code> 中的文本保持不变
+func (p *Pipeline) RewriteURLs(content []byte) []byte {
+	if len(p.assets) == 0 {
+		return content
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, loc := range codePattern.FindAllIndex(content, -1) {
+		buf.Write(p.rewriteRefs(content[last:loc[0]]))
+		buf.Write(content[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	buf.Write(p.rewriteRefs(content[last:]))
+	return buf.Bytes()
+}
+
+// rewriteRefs 替换属性和 url() 中的静态文件地址
+func (p *Pipeline) rewriteRefs(content []byte) []byte {
+	content = replaceGroups(srcPattern, content, p.rewriteRef)
+	content = replaceGroups(srcsetPattern, content, func(srcset []byte) []byte {
+		return replaceGroups(candidatePattern, srcset, p.rewriteRef)
+	})
+	return replaceGroups(cssURLPattern, content, p.rewriteRef)
+}
+
+// rewriteRef 将单个静态文件地址替换为带哈希的路径，保留原有的前缀、编码方式、查询参数和锚点
+func (p *Pipeline) rewriteRef(ref []byte) []byte {
+	loc := staticRefPattern.FindSubmatchIndex(ref)
+	if loc == nil {
+		return ref
+	}
+
+	name := string(ref[loc[2]:loc[3]])
+	unescaped, err := url.PathUnescape(name)
+	if err != nil {
+		return ref
+	}
+	asset, ok := p.assets[unescaped]
+	if !ok {
+		return ref
+	}
+
+	// 只在扩展名前插入哈希
+	ext := path.Ext(name)
+	rewritten := append([]byte{}, ref[:loc[2]]...)
+	rewritten = append(rewritten, strings.TrimSuffix(name, ext)+"."+asset.hash+ext...)
+	return append(rewritten, ref[loc[3]:]...)
+}
+
+// replaceGroups 将 re 的每个匹配中参与匹配的分组替换为 fn 的结果，其余内容保持不变
+func replaceGroups(re *regexp.Regexp, content []byte, fn func([]byte) []byte) []byte {
+	var buf bytes.Buffer
+	last := 0
+	for _, loc := range re.FindAllSubmatchIndex(content, -1) {
+		for i := 2; i < len(loc); i += 2 {
+			if loc[i] < 0 {
+				continue
+			}
+			buf.Write(content[last:loc[i]])
+			buf.Write(fn(content[loc[i]:loc[i+1]]))
+			last = loc[i+1]
+		}
+	}
+	buf.Write(content[last:])
+	return buf.Bytes()
+}
+
+// Assets 返回所有加上指纹的静态文件，键为相对于 static 目录的路径
+func (p *Pipeline) Assets() map[string]*Asset {
+	return p.assets
+}
+
+// GetIntegrity 获取资源的 SRI 哈希，name 为资源清单中的名称或 /static/ 开头的路径。
+// 资源不存在或未计算指纹时返回空字符串
+func (p *Pipeline) GetIntegrity(name string) string {
+	if rel, ok := staticName(name); ok {
+		if asset, ok := p.assets[rel]; ok {
+			return asset.Integrity
+		}
+		return ""
+	}
+	return p.integrity[name]
+}
+
+// staticName 将 /static/ 开头的路径转换为相对于 static 目录的路径
+func staticName(name string) (string, bool) {
+	if !strings.HasPrefix(name, "/static/") {
+		return "", false
+	}
+	return strings.TrimPrefix(name, "/static/"), true
+}
+
+// isDist 是否为资源管道输出的文件，这些文件名中已包含哈希
+func isDist(rel string) bool {
+	return rel == "dist" || strings.HasPrefix(rel, "dist/")
+}
+
+// integrity 计算 SRI 使用的 sha384 哈希
+func integrity(content []byte) string {
+	sum := sha512.Sum384(content)
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,74 @@
+package asset
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintSkipsHiddenFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"css/main.css":   "body{}",
+		".DS_Store":      "junk",
+		"css/.DS_Store":  "junk",
+		".cache/app.css": "a{}",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := New(t.TempDir(), "default")
+	if err := p.Fingerprint(dir); err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+
+	if got := p.GetAssetPath("/static/css/main.css"); got == "/static/css/main.css" {
+		t.Errorf("css/main.css was not fingerprinted")
+	}
+	for _, name := range []string{"/static/.DS_Store", "/static/css/.DS_Store", "/static/.cache/app.css"} {
+		if got := p.GetAssetPath(name); got != name {
+			t.Errorf("GetAssetPath(%q) = %q, hidden files should not be fingerprinted", name, got)
+		}
+	}
+}
+
+func TestRewriteURLs(t *testing.T) {
+	p := New(t.TempDir(), "default")
+	assets := p.FingerprintFiles(map[string][]byte{
+		"css/main.css":   []byte("body{}"),
+		"images/a b.png": []byte("png"),
+	})
+	css := "/static/" + assets["css/main.css"].Path
+	png := strings.TrimSuffix(assets["images/a b.png"].Path, ".png")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"root relative", `<link href="/static/css/main.css">`, `<link href="` + css + `">`},
+		{"page relative", `<link href='../../static/css/main.css?v=1'>`, `<link href='../../` + css[1:] + `?v=1'>`},
+		{"current dir", `<img src="./static/images/a%20b.png">`, `<img src="./static/` + strings.ReplaceAll(png, " ", "%20") + `.png">`},
+		{"srcset", `<img srcset="/static/images/a%20b.png 1x, /static/css/main.css 2x">`, `<img srcset="/static/` + strings.ReplaceAll(png, " ", "%20") + `.png 1x, ` + css + ` 2x">`},
+		{"css url", `<div style="background:url('/static/css/main.css')">`, `<div style="background:url('` + css + `')">`},
+		{"external url", `<script src="https://cdn.example.com/static/css/main.css"></script>`, `<script src="https://cdn.example.com/static/css/main.css"></script>`},
+		{"unknown file", `<img src="/static/images/missing.png">`, `<img src="/static/images/missing.png">`},
+		{"text", `<p>see /static/css/main.css</p>`, `<p>see /static/css/main.css</p>`},
+		{"code", `<pre><code><img src="/static/css/main.css"></code></pre><code>url(/static/css/main.css)</code>`, `<pre><code><img src="/static/css/main.css"></code></pre><code>url(/static/css/main.css)</code>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(p.RewriteURLs([]byte(tt.content))); got != tt.want {
+				t.Errorf("RewriteURLs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,15 +4,24 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/jiangjiax/stars/internal/theme"
 )
 
+// ManifestFile 资源清单，记录资源名到带哈希的文件名的映射
+const ManifestFile = "manifest.json"
+
 type Pipeline struct {
 	projectDir string
 	assetMap   map[string]string // 用于存储资源映射
+	integrity  map[string]string // 资源清单中各文件的 SRI 哈希
+	assets     map[string]*Asset // 加上指纹的静态文件，键为相对于 static 目录的路径
 	theme      string
 }
 
@@ -20,41 +29,47 @@ func New(projectDir string, theme string) *Pipeline {
 	return &Pipeline{
 		projectDir: projectDir,
 		assetMap:   make(map[string]string),
+		integrity:  make(map[string]string),
+		assets:     make(map[string]*Asset),
 		theme:      theme,
 	}
 }
 
-// BuildAssets 构建所有资源
+// BuildAssets 构建所有资源。CSS 和 JS 由 esbuild 打包压缩，
+// 只有主题在 theme.yaml 中设置 assets.npm 时才运行 npm
 func (p *Pipeline) BuildAssets() error {
-	// 在构建新资源之前清理旧文件
-	if err := p.CleanOldAssets(); err != nil {
+	assets, err := p.loadThemeAssets()
+	if err != nil {
 		return err
 	}
 
-	// 先构建 CSS
-	if err := p.BuildCSS(); err != nil {
-		return fmt.Errorf("failed to build CSS: %w", err)
-	}
+	// 上次构建（或主题自带）的资源，打包失败时作为后备
+	prebuilt := p.readManifest()
 
-	// 构建 JS
-	fmt.Println("Building JavaScript...")
-	jsCmd := exec.Command("npm", "run", "build:js")
-	jsCmd.Dir = filepath.Join(p.projectDir, "themes", p.theme)
-	jsCmd.Stdout = os.Stdout
-	jsCmd.Stderr = os.Stderr
-	if err := jsCmd.Run(); err != nil {
-		return fmt.Errorf("failed to build JavaScript: %w", err)
+	if assets.NPM {
+		if err := p.runNPM(); err != nil {
+			return err
+		}
+		// npm 构建的 JS 及其清单直接使用
+		prebuilt = p.readManifest()
+		for name, file := range prebuilt {
+			if strings.HasSuffix(name, ".js") {
+				p.assetMap[name] = file
+			}
+		}
 	}
 
-	// 读取生成的资源映射
-	assetManifest := filepath.Join(p.projectDir, "themes", p.theme, "static/dist/manifest.json")
-	data, err := os.ReadFile(assetManifest)
-	if err != nil {
-		return fmt.Errorf("failed to read asset manifest: %w", err)
+	fmt.Println("Building CSS...")
+	for _, entry := range p.cssEntries(assets) {
+		// styles.built.css 是 Tailwind 的构建结果，资源名仍为 styles.css
+		p.bundle(entry, strings.Replace(filepath.Base(entry), ".built.css", ".css", 1), prebuilt)
 	}
 
-	if err := json.Unmarshal(data, &p.assetMap); err != nil {
-		return fmt.Errorf("failed to parse asset manifest: %w", err)
+	if !assets.NPM {
+		fmt.Println("Building JavaScript...")
+		for _, entry := range p.jsEntries(assets) {
+			p.bundle(entry, strings.TrimSuffix(filepath.Base(entry), ".js")+".bundle.js", prebuilt)
+		}
 	}
 
 	// 处理 ABI 文件
@@ -62,74 +77,222 @@ func (p *Pipeline) BuildAssets() error {
 		return fmt.Errorf("failed to process ABI files: %w", err)
 	}
 
+	if err := p.CleanOldAssets(); err != nil {
+		return err
+	}
+
+	// 计算 SRI 哈希，供模板输出 integrity 属性
+	for name, file := range p.assetMap {
+		content, err := os.ReadFile(filepath.Join(p.distDir(), filepath.FromSlash(file)))
+		if err != nil {
+			return fmt.Errorf("failed to read asset %s: %w", file, err)
+		}
+		p.integrity[name] = integrity(content)
+	}
+
+	return p.writeManifest()
+}
+
+// loadThemeAssets 读取主题的资源配置，没有 theme.yaml 时使用默认配置
+func (p *Pipeline) loadThemeAssets() (theme.Assets, error) {
+	t, err := theme.Load(p.themeDir())
+	if err != nil {
+		if _, statErr := os.Stat(filepath.Join(p.themeDir(), "theme.yaml")); os.IsNotExist(statErr) {
+			return theme.Assets{}, nil
+		}
+		return theme.Assets{}, err
+	}
+	return t.Assets, nil
+}
+
+// cssEntries CSS 入口文件，未配置时优先使用预先构建的 styles.built.css
+func (p *Pipeline) cssEntries(assets theme.Assets) []string {
+	if len(assets.CSS) > 0 {
+		return p.staticPaths(assets.CSS)
+	}
+
+	for _, name := range []string{"styles.built.css", "styles.css"} {
+		path := filepath.Join(p.staticDir(), "css", name)
+		if _, err := os.Stat(path); err == nil {
+			return []string{path}
+		}
+	}
 	return nil
 }
 
-// BuildCSS 构建 CSS 文件
-func (p *Pipeline) BuildCSS() error {
-	// 检查必要文件是否存在
-	files := []string{
-		filepath.Join("themes", p.theme, "package.json"),
-		filepath.Join("themes", p.theme, "tailwind.config.js"),
-		filepath.Join("themes", p.theme, "static", "css", "styles.css"),
+// jsEntries JS 入口文件，未配置时使用 js 目录下的所有 .js 文件
+func (p *Pipeline) jsEntries(assets theme.Assets) []string {
+	if len(assets.JS) > 0 {
+		return p.staticPaths(assets.JS)
 	}
 
-	for _, file := range files {
-		if _, err := os.Stat(filepath.Join(p.projectDir, file)); os.IsNotExist(err) {
-			return fmt.Errorf("required file not found: %s, dir: %s", file, p.projectDir)
+	entries, _ := filepath.Glob(filepath.Join(p.staticDir(), "js", "*.js"))
+	return entries
+}
+
+func (p *Pipeline) staticPaths(names []string) []string {
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(p.staticDir(), filepath.FromSlash(name)))
+	}
+	return paths
+}
+
+// bundle 打包压缩入口文件并以内容哈希命名，写入 dist 目录。
+// 打包失败时使用之前构建的同名资源
+func (p *Pipeline) bundle(entry, name string, prebuilt map[string]string) {
+	file, err := p.build(entry, name)
+	if err == nil {
+		p.assetMap[name] = file
+		return
+	}
+
+	if file, ok := prebuilt[name]; ok {
+		if _, statErr := os.Stat(filepath.Join(p.distDir(), filepath.FromSlash(file))); statErr == nil {
+			log.Printf("Warning: failed to bundle %s, using prebuilt %s: %v", name, file, err)
+			p.assetMap[name] = file
+			return
+		}
+	}
+	log.Printf("Warning: failed to bundle %s: %v", name, err)
+}
+
+// build 调用 esbuild 打包，返回 dist 目录下的文件名
+func (p *Pipeline) build(entry, name string) (string, error) {
+	result := api.Build(api.BuildOptions{
+		EntryPoints:       []string{entry},
+		Outdir:            p.distDir(),
+		AssetNames:        "[name].[hash]",
+		Bundle:            true,
+		Write:             false,
+		MinifyWhitespace:  true,
+		MinifyIdentifiers: true,
+		MinifySyntax:      true,
+		// 输出 ES 模块，从 CDN 导入的依赖（如 https://esm.sh/ethers）由浏览器加载，
+		// 主题不需要 node_modules
+		Format: api.FormatESModule,
+		Target: api.ES2017,
+		// 站点静态文件（如 url(/static/images/bg.png)）和 CDN 上的模块不参与打包
+		External: []string{"/static/*", "https://*"},
+		Loader: map[string]api.Loader{
+			".png":   api.LoaderFile,
+			".jpg":   api.LoaderFile,
+			".gif":   api.LoaderFile,
+			".svg":   api.LoaderFile,
+			".woff":  api.LoaderFile,
+			".woff2": api.LoaderFile,
+			".ttf":   api.LoaderFile,
+		},
+		LogLevel: api.LogLevelSilent,
+	})
+	if len(result.Errors) > 0 {
+		msg := result.Errors[0]
+		if msg.Location != nil {
+			return "", fmt.Errorf("%s:%d: %s", msg.Location.File, msg.Location.Line, msg.Text)
 		}
+		return "", fmt.Errorf("%s", msg.Text)
+	}
+
+	if err := os.MkdirAll(p.distDir(), 0755); err != nil {
+		return "", fmt.Errorf("failed to create dist directory: %w", err)
+	}
+
+	ext := filepath.Ext(name)
+	var output string
+	for _, out := range result.OutputFiles {
+		path := out.Path
+		// 入口的输出文件以内容哈希重命名，图片、字体等已由 esbuild 命名
+		if output == "" && filepath.Ext(path) == ext {
+			output = fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), contentHash(out.Contents), ext)
+			path = filepath.Join(p.distDir(), output)
+		}
+		if err := os.WriteFile(path, out.Contents, 0644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if output == "" {
+		return "", fmt.Errorf("no output generated for %s", entry)
+	}
+	return output, nil
+}
+
+// runNPM 安装依赖并运行主题 package.json 中的 build:css 和 build:js 脚本
+func (p *Pipeline) runNPM() error {
+	themeDir := p.themeDir()
+	data, err := os.ReadFile(filepath.Join(themeDir, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
 	}
 
 	// 检查 npm 是否安装
 	if _, err := exec.LookPath("npm"); err != nil {
-		return fmt.Errorf("node.js and npm are required. Please install them first: %w", err)
+		return fmt.Errorf("node.js and npm are required by theme %s. Please install them first: %w", p.theme, err)
 	}
 
-	// 检查 node_modules 是否存在且完整
-	nodeModulesPath := filepath.Join(p.projectDir, "themes", p.theme, "node_modules")
-	tailwindPath := filepath.Join(nodeModulesPath, "tailwindcss")
-	if _, err := os.Stat(tailwindPath); os.IsNotExist(err) {
-		// 安装依赖
+	// 检查 node_modules 是否存在
+	if _, err := os.Stat(filepath.Join(themeDir, "node_modules")); os.IsNotExist(err) {
 		fmt.Println("Installing dependencies...")
-		installCmd := exec.Command("npm", "install")
-		installCmd.Dir = filepath.Join(p.projectDir, "themes", p.theme)
-		installCmd.Stdout = os.Stdout
-		installCmd.Stderr = os.Stderr
-		if err := installCmd.Run(); err != nil {
+		if err := p.npm("install"); err != nil {
 			return fmt.Errorf("failed to install dependencies: %w", err)
 		}
-	} else {
-		fmt.Println("Dependencies already installed, skipping npm install")
 	}
 
-	// 构建 CSS
-	fmt.Println("Building CSS...")
-	buildCmd := exec.Command("npm", "run", "build:css")
-	buildCmd.Dir = filepath.Join(p.projectDir, "themes", p.theme)
-	buildCmd.Stdout = os.Stdout
-	buildCmd.Stderr = os.Stderr
-	if err := buildCmd.Run(); err != nil {
-		return fmt.Errorf("failed to build CSS: %w", err)
+	for _, script := range []string{"build:css", "build:js"} {
+		if _, ok := pkg.Scripts[script]; !ok {
+			continue
+		}
+		fmt.Printf("Running npm run %s...\n", script)
+		if err := p.npm("run", script); err != nil {
+			return fmt.Errorf("failed to run %s: %w", script, err)
+		}
 	}
-
 	return nil
 }
 
-// 添加获取资源路径的方法
+func (p *Pipeline) npm(args ...string) error {
+	cmd := exec.Command("npm", args...)
+	cmd.Dir = p.themeDir()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// 添加获取资源路径的方法。name 为 /static/ 开头的路径时，返回加上指纹的路径
 func (p *Pipeline) GetAssetPath(name string) string {
+	if rel, ok := staticName(name); ok {
+		if asset, ok := p.assets[rel]; ok {
+			return "/static/" + asset.Path
+		}
+		return name
+	}
 	if hash, ok := p.assetMap[name]; ok {
 		return hash
 	}
 	return name
 }
 
-// CleanOldAssets 清理旧的资源文件
-func (p *Pipeline) CleanOldAssets() error {
-	// 获取 dist 目录
-	distDir := filepath.Join(p.projectDir, "themes", p.theme, "static/dist")
+// HasAsset 资源清单中是否有该资源，打包失败且没有可用的预构建文件时为 false
+func (p *Pipeline) HasAsset(name string) bool {
+	_, ok := p.assetMap[name]
+	return ok
+}
 
+// StaticDirs 静态文件目录，项目的 static 目录覆盖主题中的同名文件
+func (p *Pipeline) StaticDirs() []string {
+	return []string{p.staticDir(), filepath.Join(p.projectDir, "static")}
+}
+
+// CleanOldAssets 清理不在资源清单中的旧 .js 和 .css 文件
+func (p *Pipeline) CleanOldAssets() error {
 	// 读取目录下所有文件
-	entries, err := os.ReadDir(distDir)
+	entries, err := os.ReadDir(p.distDir())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // dist 目录不存在时直接返回
@@ -137,19 +300,71 @@ func (p *Pipeline) CleanOldAssets() error {
 		return fmt.Errorf("failed to read dist directory: %w", err)
 	}
 
-	// 删除所有 .js 文件
+	current := make(map[string]bool, len(p.assetMap))
+	for _, file := range p.assetMap {
+		current[file] = true
+	}
+
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".js") {
-			filePath := filepath.Join(distDir, entry.Name())
-			if err := os.Remove(filePath); err != nil {
-				return fmt.Errorf("failed to remove old asset file %s: %w", filePath, err)
-			}
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".js" && ext != ".css") || current[entry.Name()] {
+			continue
+		}
+		filePath := filepath.Join(p.distDir(), entry.Name())
+		if err := os.Remove(filePath); err != nil {
+			return fmt.Errorf("failed to remove old asset file %s: %w", filePath, err)
 		}
 	}
 
 	return nil
 }
 
+// readManifest 读取 dist 目录下已有的资源清单
+func (p *Pipeline) readManifest() map[string]string {
+	manifest := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(p.distDir(), ManifestFile))
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		log.Printf("Warning: failed to parse asset manifest: %v", err)
+	}
+	return manifest
+}
+
+// writeManifest 写入资源清单
+func (p *Pipeline) writeManifest() error {
+	data, err := json.MarshalIndent(p.assetMap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal asset manifest: %w", err)
+	}
+	if err := os.MkdirAll(p.distDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create dist directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(p.distDir(), ManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write asset manifest: %w", err)
+	}
+	return nil
+}
+
+func (p *Pipeline) themeDir() string {
+	return filepath.Join(p.projectDir, "themes", p.theme)
+}
+
+func (p *Pipeline) staticDir() string {
+	return filepath.Join(p.themeDir(), "static")
+}
+
+func (p *Pipeline) distDir() string {
+	return filepath.Join(p.staticDir(), "dist")
+}
+
+// contentHash 内容哈希，用于资源文件名
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("%x", sum[:8])
+}
+
 // processABIFiles 处理 ABI 文件
 func (p *Pipeline) processABIFiles() error {
 	// ABI 文件源目录
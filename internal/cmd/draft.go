@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 	"os"
 	"path/filepath"
+	"github.com/jiangjiax/stars/internal/config"
 	"github.com/jiangjiax/stars/internal/post"
 )
 
@@ -23,7 +24,12 @@ var draftListCmd = &cobra.Command{
 			return fmt.Errorf("failed to get working directory: %w", err)
 		}
 
-		posts, err := post.ParsePosts(filepath.Join(projectDir, "content"))
+		cfg, err := config.LoadConfig(filepath.Join(projectDir, "config.yaml"))
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		loader := post.NewLoader(projectDir, cfg)
+		posts, err := loader.LoadPosts(filepath.Join(projectDir, "content"))
 		if err != nil {
 			return err
 		}
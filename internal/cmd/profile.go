@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/cv"
+	"github.com/jiangjiax/stars/internal/github"
+	"github.com/jiangjiax/stars/internal/resume"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileOutput    string
+	profilePDFOutput string
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the author profile",
+	Long: `Import and export the author profile in the JSON Resume format, print it as a PDF,
+or sync project stats from GitHub.`,
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import [resume.json]",
+	Short: "Import a JSON Resume into config.yaml",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		configPath := filepath.Join(projectDir, "config.yaml")
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		r, err := resume.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		// 合并到现有的个人资料，只更新简历中包含的部分
+		r.Apply(&cfg.Author)
+		if err := config.SaveProfile(configPath, cfg.Author); err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported profile from %s\n", args[0])
+		return nil
+	},
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the author profile as JSON Resume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		cfg, err := config.LoadConfig(filepath.Join(projectDir, "config.yaml"))
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		data, err := resume.FromConfig(cfg).JSON()
+		if err != nil {
+			return err
+		}
+
+		// 未指定输出文件时打印到标准输出
+		if profileOutput == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(profileOutput, data, 0644); err != nil {
+			return fmt.Errorf("failed to write resume: %w", err)
+		}
+		fmt.Printf("Exported profile to %s\n", profileOutput)
+		return nil
+	},
+}
+
+var profilePDFCmd = &cobra.Command{
+	Use:   "pdf",
+	Short: "Render the author profile to a PDF using the theme's print layout",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		cfg, err := config.LoadConfig(filepath.Join(projectDir, "config.yaml"))
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if err := cv.Save(projectDir, cfg, profilePDFOutput); err != nil {
+			return err
+		}
+		fmt.Printf("Generated CV: %s\n", profilePDFOutput)
+		return nil
+	},
+}
+
+var profileSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync stars, forks, language and topics of GitHub projects into config.yaml",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		configPath := filepath.Join(projectDir, "config.yaml")
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		client, err := github.NewClient(cfg.GitHub, filepath.Join(projectDir, ".cache", "github"))
+		if err != nil {
+			return err
+		}
+
+		updated := client.SyncProjects(&cfg.Author)
+		if err := config.SaveProfile(configPath, cfg.Author); err != nil {
+			return err
+		}
+
+		fmt.Printf("Synced %d of %d projects\n", updated, len(cfg.Author.Projects))
+		return nil
+	},
+}
+
+func init() {
+	profileExportCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "write the resume to a file instead of stdout")
+	profilePDFCmd.Flags().StringVarP(&profilePDFOutput, "output", "o", "cv.pdf", "output PDF file")
+	profileCmd.AddCommand(profileImportCmd)
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profilePDFCmd)
+	profileCmd.AddCommand(profileSyncCmd)
+	rootCmd.AddCommand(profileCmd)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"math/big"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"text/template"
@@ -37,6 +39,48 @@ type Series struct {
 	Order       int    `yaml:"order"`
 }
 
+// Taxonomy 分类，Name 既是文章 front matter 中的字段名，也是分类页面的 URL 路径
+type Taxonomy struct {
+	Name  string `yaml:"name"`  // 如 categories，页面地址为 /categories/
+	Title string `yaml:"title"` // 显示名称，如 "分类"
+}
+
+// Author 团队博客中的作者，在 config.yaml 的 authors 中以作者 ID 为键配置
+type Author struct {
+	ID            string `yaml:"-"`             // 作者 ID，即 authors 中的键
+	Name          string `yaml:"name"`          // 显示名称
+	Avatar        string `yaml:"avatar"`        // 头像
+	Bio           string `yaml:"bio"`           // 个人简介
+	WalletAddress string `yaml:"walletAddress"` // 钱包地址，用于接收文章 NFT 收益
+	GitHub        string `yaml:"github"`        // GitHub 用户名
+	Twitter       string `yaml:"twitter"`       // Twitter 用户名
+	Website       string `yaml:"website"`       // 个人网站
+}
+
+// URL 作者页面地址
+func (a *Author) URL() string {
+	return "/authors/" + a.ID
+}
+
+// ReservedPaths 生成的页面和文件占用的顶层路径，分区和自定义分类不能使用
+var ReservedPaths = []string{"posts", "archives", "static", "feed.xml", "graph.json", "sitemap.xml", "robots.txt", "resume.json", "cv.pdf", "_images"}
+
+// IsReservedPath 顶层路径是否已被生成的页面或文件占用
+func IsReservedPath(name string) bool {
+	for _, reserved := range ReservedPaths {
+		if name == reserved {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTaxonomies 内置的标签和系列分类
+var DefaultTaxonomies = []Taxonomy{
+	{Name: "tags", Title: "标签"},
+	{Name: "series", Title: "系列"},
+}
+
 // NFT 参数限制
 const (
 	MinPrice      = "0.001" // 最小价格 0.001 ETH
@@ -79,23 +123,8 @@ func (c *NFTConfig) ValidateNFTConfig() error {
 	return nil
 }
 
-// GetDefaultNFTConfig 获取默认的 NFT 配置
-func GetDefaultNFTConfig() *NFTConfig {
-	cfg, err := LoadConfig("config.yaml")
-	if err == nil && cfg != nil && cfg.Verification != nil && cfg.Verification.NFT != nil {
-		// 从配置文件获取默认值
-		return &NFTConfig{
-			Price:         cfg.Verification.NFT.Price,
-			MaxSupply:     cfg.Verification.NFT.MaxSupply,
-			RoyaltyFee:    cfg.Verification.NFT.RoyaltyFee,
-			OnePerAddress: cfg.Verification.NFT.OnePerAddress,
-			Version:       cfg.Verification.NFT.Version,
-			ChainId:       cfg.Verification.NFT.ChainId,
-			TokenSymbol:   cfg.Verification.NFT.TokenSymbol,
-		}
-	}
-
-	// 如果配置文件中没有设置，使用硬编码的默认值
+// DefaultNFTConfig 内置的 NFT 默认配置，站点配置、分区 cascade 和文章 front matter 会依次覆盖其中的值
+func DefaultNFTConfig() *NFTConfig {
 	return &NFTConfig{
 		Price:         "0",
 		MaxSupply:     9999,
@@ -103,7 +132,7 @@ func GetDefaultNFTConfig() *NFTConfig {
 		OnePerAddress: true,
 		Version:       "1.0.0",
 		ChainId:       1,
-		TokenSymbol:   "ETH",  // 默认使用 ETH
+		TokenSymbol:   "ETH", // 默认使用 ETH
 	}
 }
 
@@ -112,102 +141,9 @@ type Config struct {
 	Description string `yaml:"description"`
 	BaseURL     string `yaml:"baseURL"`
 	Theme       string `yaml:"theme"`
+	Language    string `yaml:"language"` // 站点语言，如 zh-CN、en，默认 zh-CN
 
-	// 个人信息
-	Author struct {
-		Name          string `yaml:"name"`
-		Title         string `yaml:"title"`
-		Avatar        string `yaml:"avatar"`
-		Bio           string `yaml:"bio"`
-		Location      string `yaml:"location"`
-		GitHub        string `yaml:"github"`
-		Status        string `yaml:"status"`
-		WalletAddress string `yaml:"walletAddress"`
-
-		// 技术栈
-		Skills []struct {
-			Name       string `yaml:"name"`
-			Level      string `yaml:"level"`
-			Percentage int    `yaml:"percentage"`
-		} `yaml:"skills"`
-
-		// 项目展示
-		Projects []struct {
-			Name        string   `yaml:"name"`
-			Description string   `yaml:"description"`
-			URL         string   `yaml:"url"`     // GitHub 仓库地址
-			Website     string   `yaml:"website"` // 项目网址
-			Image       string   `yaml:"image"`
-			Highlights  []string `yaml:"highlights"`
-			Tags        []string `yaml:"tags"`
-			Stars       int      `yaml:"stars"`
-			Forks       int      `yaml:"forks"`
-		} `yaml:"projects"`
-
-		// 开源贡献
-		Contributions []struct {
-			Name          string   `yaml:"name"`
-			Description   string   `yaml:"description"`
-			URL           string   `yaml:"url"`
-			Contributions []string `yaml:"contributions"`
-			Impact        string   `yaml:"impact"`
-		} `yaml:"contributions"`
-
-		// 社交链接
-		SocialLinks []struct {
-			Name string `yaml:"name"`
-			URL  string `yaml:"url"`
-			Icon string `yaml:"icon"`
-		} `yaml:"socialLinks"`
-
-		// 教育背景
-		Education []struct {
-			School       string   `yaml:"school"`
-			Degree       string   `yaml:"degree"`
-			Major        string   `yaml:"major"`
-			Year         string   `yaml:"year"`
-			Achievements []string `yaml:"achievements"`
-		} `yaml:"education"`
-
-		// 工作经历
-		Experience []struct {
-			Company          string   `yaml:"company"`
-			Position         string   `yaml:"position"`
-			Period           string   `yaml:"period"`
-			Responsibilities []string `yaml:"responsibilities"`
-			Technologies     []string `yaml:"technologies"`
-		} `yaml:"experience"`
-
-		// 证书和资质
-		Certifications []struct {
-			Name   string `yaml:"name"`
-			Issuer string `yaml:"issuer"`
-			Date   string `yaml:"date"`
-			Icon   string `yaml:"icon"`
-		} `yaml:"certifications"`
-
-		// 联系方式
-		Contact struct {
-			Email       string `yaml:"email"`
-			WeChat      string `yaml:"wechat"`
-			Phone       string `yaml:"phone"`
-			Telegram    string `yaml:"telegram"`
-			Twitter     string `yaml:"twitter"`
-			Bilibili    string `yaml:"bilibili"`     // B站用户名/UID
-			Weibo       string `yaml:"weibo"`        // 微博用户名/ID
-			Douyin      string `yaml:"douyin"`       // 抖音号
-			Xiaohongshu string `yaml:"xiaohongshu"`  // 小红书号
-			Juejin      string `yaml:"juejin"`       // 掘金用户名
-			Jike        string `yaml:"jike"`         // 即刻用户名
-		} `yaml:"contact"`
-
-		// 推荐的文章系列
-		RecommendedSeries []struct {
-			Name        string `yaml:"name"`
-			Description string `yaml:"description"`
-			Icon        string `yaml:"icon"`
-		} `yaml:"recommendedSeries"`
-	} `yaml:"author"`
+	Author Profile `yaml:"author"` // 个人信息
 
 	// 博客设置
 	Blog struct {
@@ -220,10 +156,36 @@ type Config struct {
 
 	Series []Series `yaml:"series"`
 
+	Taxonomies []Taxonomy `yaml:"taxonomies"` // 自定义分类
+
+	Authors map[string]*Author `yaml:"authors"` // 作者 ID -> 作者信息，用于多作者博客
+
+	Cascade map[string]interface{} `yaml:"cascade"` // 所有内容的默认 front matter
+
+	verificationParams map[string]interface{} // 配置文件中原始的 verification，只包含实际配置的字段
+
+	Data map[string]interface{} `yaml:"-"` // data 目录下的数据文件，模板中通过 .Site.Data 访问
+
 	Newsletter Newsletter `yaml:"newsletter"`
 
 	SEO SEO `yaml:"seo"`
 
+	Markup Markup `yaml:"markup"`
+
+	CV CV `yaml:"cv"`
+
+	GitHub GitHub `yaml:"github"`
+
+	Imaging Imaging `yaml:"imaging"`
+
+	OGImage OGImage `yaml:"ogImage"`
+
+	Feeds Feeds `yaml:"feeds"`
+
+	Podcast Podcast `yaml:"podcast"`
+
+	GitInfo GitInfo `yaml:"gitInfo"`
+
 	Verification *Verification `yaml:"verification"` // 使用指针允许为空
 }
 
@@ -241,6 +203,236 @@ type Newsletter struct {
 // SEO 配置
 type SEO struct {
 	Keywords []string `yaml:"keywords"`
+	Image    string   `yaml:"image"`    // 默认分享图片，文章没有生成分享图片时使用
+	Robots   string   `yaml:"robots"`   // 默认的 robots 指令，如 "index, follow"，文章可以通过 front matter 中的 robots 覆盖
+	Disallow []string `yaml:"disallow"` // robots.txt 中禁止抓取的路径，如 /drafts/
+}
+
+// Markup Markdown 渲染配置
+type Markup struct {
+	Math      Math      `yaml:"math"`      // 数学公式
+	Highlight Highlight `yaml:"highlight"` // 代码高亮
+}
+
+// Math 数学公式配置
+type Math struct {
+	Enabled bool `yaml:"enabled"` // 是否启用 $...$ 和 $$...$$ 公式，构建时渲染为 MathML
+}
+
+// Highlight 代码高亮配置
+type Highlight struct {
+	Style         string `yaml:"style"`         // chroma 样式名称，默认 monokai
+	DarkStyle     string `yaml:"darkStyle"`     // 深色模式下使用的样式，为空时与 style 相同
+	NoClasses     bool   `yaml:"noClasses"`     // 输出内联样式而不是 CSS 类，此时不生成 chroma.css；未配置时为 true
+	LineNos       bool   `yaml:"lineNos"`       // 默认显示行号，可在代码块中用 linenos=false 关闭
+	GuessLanguage bool   `yaml:"guessLanguage"` // 未指定语言时自动识别；未配置时为 true
+	TabWidth      int    `yaml:"tabWidth"`      // Tab 宽度
+}
+
+// DefaultHighlight 未配置 markup.highlight 时的代码高亮设置，与早期版本一致：
+// monokai 内联样式，不需要主题引入 chroma.css，未指定语言时自动识别
+func DefaultHighlight() Highlight {
+	return Highlight{Style: "monokai", NoClasses: true, GuessLanguage: true}
+}
+
+// CV 简历 PDF 配置
+type CV struct {
+	Enabled  bool   `yaml:"enabled"`  // 构建时生成 /cv.pdf
+	Font     string `yaml:"font"`     // TrueType 字体文件，需要包含中文字形，为空时自动查找
+	BoldFont string `yaml:"boldFont"` // 粗体字体文件，为空时使用 font
+	PageSize string `yaml:"pageSize"` // 纸张大小，A4（默认）、A5、Letter 或 Legal
+}
+
+// GitHub 个人资料中 GitHub 项目数据的同步配置
+type GitHub struct {
+	Sync     bool   `yaml:"sync"`     // 构建时同步项目的星标、fork、语言、主题和最后推送时间
+	APIURL   string `yaml:"apiURL"`   // REST API 地址，默认 https://api.github.com
+	Token    string `yaml:"token"`    // 访问令牌，为空时使用 GITHUB_TOKEN 环境变量
+	CacheTTL string `yaml:"cacheTTL"` // 缓存有效期，如 "6h"，默认 24h
+}
+
+// OGImage 文章分享图片（og:image）配置
+type OGImage struct {
+	Enabled  bool   `yaml:"enabled"`  // 为每篇文章生成 1200×630 的 PNG 分享图片
+	Font     string `yaml:"font"`     // TrueType 字体文件，需要包含中文字形，为空时自动查找
+	BoldFont string `yaml:"boldFont"` // 标题使用的粗体字体，为空时使用 font
+}
+
+// Feeds 订阅源配置
+type Feeds struct {
+	Formats     []string `yaml:"formats"`     // 输出的格式：rss、atom、json，默认全部
+	Limit       int      `yaml:"limit"`       // 站点订阅源的文章数量，默认 20，-1 表示全部
+	TermLimit   int      `yaml:"termLimit"`   // 标签、系列、作者等分类订阅源的文章数量，默认 10，-1 表示全部
+	FullContent bool     `yaml:"fullContent"` // 输出文章的完整 HTML，否则只输出描述
+}
+
+// Enabled 是否输出指定格式的订阅源
+func (f Feeds) Enabled(format string) bool {
+	if f.Formats == nil {
+		return true
+	}
+	for _, name := range f.Formats {
+		if strings.EqualFold(name, format) {
+			return true
+		}
+	}
+	return false
+}
+
+// Podcast 播客订阅源配置，front matter 中有 audio 的文章会出现在 /podcast.xml 中
+type Podcast struct {
+	Enabled     bool   `yaml:"enabled"`     // 生成 /podcast.xml
+	Title       string `yaml:"title"`       // 播客名称，为空时使用站点标题
+	Description string `yaml:"description"` // 播客简介，为空时使用站点描述
+	Image       string `yaml:"image"`       // 封面，Apple Podcasts 要求 1400×1400 到 3000×3000 的 JPEG 或 PNG
+	Author      string `yaml:"author"`      // 主播，为空时使用站点作者
+	Email       string `yaml:"email"`       // 所有者邮箱，用于播客平台验证
+	Category    string `yaml:"category"`    // Apple Podcasts 分类，如 Technology
+	Explicit    bool   `yaml:"explicit"`    // 是否包含不适宜未成年人的内容
+	Type        string `yaml:"type"`        // episodic（默认，按时间倒序）或 serial（按集数顺序）
+}
+
+// GitInfo 从 git 仓库读取文章的修改记录
+type GitInfo struct {
+	Enabled bool   `yaml:"enabled"` // 使用文章最后一次提交的时间作为 lastmod，并读取文章的修改记录
+	RepoURL string `yaml:"repoURL"` // 仓库的网页地址，如 https://github.com/user/blog，用于生成提交链接
+}
+
+// Imaging 构建时的图片处理配置
+type Imaging struct {
+	Enabled bool     `yaml:"enabled"` // 为 static 下的图片生成多种宽度和格式，Markdown 图片输出 <picture>
+	Widths  []int    `yaml:"widths"`  // 生成的宽度，默认 480、960、1600，不超过原图宽度
+	Formats []string `yaml:"formats"` // 额外生成的格式，默认 webp；同时保留原图格式作为后备
+	Quality int      `yaml:"quality"` // JPEG 质量，默认 80
+}
+
+// AbsURL 将站内路径拼接为基于 baseURL 的完整地址，p 已是完整地址或 baseURL 为空时原样返回
+func AbsURL(baseURL, p string) string {
+	if u, err := url.Parse(p); err == nil && u.IsAbs() {
+		return p
+	}
+	if baseURL == "" {
+		return p
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(p, "/")
+}
+
+// LanguageCode 站点语言，未配置时为 zh-CN
+func (c *Config) LanguageCode() string {
+	if c.Language == "" {
+		return "zh-CN"
+	}
+	return c.Language
+}
+
+// GetAuthor 根据作者 ID 获取作者信息
+func (c *Config) GetAuthor(id string) (*Author, bool) {
+	author, ok := c.Authors[id]
+	return author, ok && author != nil
+}
+
+// GetTaxonomies 获取所有分类：内置的标签、系列、作者以及配置中的自定义分类，
+// 配置中与内置分类同名的项会覆盖其显示名称
+func (c *Config) GetTaxonomies() []Taxonomy {
+	taxonomies := make([]Taxonomy, len(DefaultTaxonomies))
+	copy(taxonomies, DefaultTaxonomies)
+
+	// 配置了多个作者时生成作者页面
+	if len(c.Authors) > 0 {
+		taxonomies = append(taxonomies, Taxonomy{Name: "authors", Title: "作者"})
+	}
+
+	for _, t := range c.Taxonomies {
+		if t.Name == "" {
+			continue
+		}
+		if t.Title == "" {
+			t.Title = t.Name
+		}
+
+		found := false
+		for i := range taxonomies {
+			if taxonomies[i].Name == t.Name {
+				taxonomies[i].Title = t.Title
+				found = true
+				break
+			}
+		}
+		if !found {
+			taxonomies = append(taxonomies, t)
+		}
+	}
+	return taxonomies
+}
+
+// validateTaxonomies 检查自定义分类的名称，分类页面位于 /名称/，不能与固定的页面路径冲突
+func (c *Config) validateTaxonomies() error {
+	for _, t := range c.Taxonomies {
+		if IsReservedPath(t.Name) {
+			return fmt.Errorf("taxonomy %q conflicts with the generated /%s/ pages", t.Name, t.Name)
+		}
+	}
+	return nil
+}
+
+// FrontMatterDefaults 获取站点级的默认 front matter，
+// 依次合并内置的 NFT 默认值、配置中的 verification 和 cascade
+func (c *Config) FrontMatterDefaults() map[string]interface{} {
+	defaults := map[string]interface{}{
+		"verification": map[string]interface{}{
+			"nft": toParams(DefaultNFTConfig()),
+		},
+	}
+
+	verification := c.verificationParams
+	if verification == nil && c.Verification != nil {
+		verification = toParams(c.Verification)
+	}
+	if verification != nil {
+		defaults = MergeParams(defaults, map[string]interface{}{"verification": verification})
+	}
+
+	return MergeParams(defaults, c.Cascade)
+}
+
+// MergeParams 深度合并两组 front matter，src 中的值覆盖 dst，嵌套的 map 逐层合并，列表整体替换。
+// 返回新的 map，不修改参数
+func MergeParams(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = cloneParam(v)
+	}
+	for k, v := range src {
+		srcMap, srcOK := v.(map[string]interface{})
+		dstMap, dstOK := merged[k].(map[string]interface{})
+		if srcOK && dstOK {
+			merged[k] = MergeParams(dstMap, srcMap)
+		} else {
+			merged[k] = cloneParam(v)
+		}
+	}
+	return merged
+}
+
+// cloneParam 复制 front matter 中的嵌套 map，避免合并结果之间共享
+func cloneParam(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return MergeParams(nil, m)
+	}
+	return v
+}
+
+// toParams 将结构体按 yaml 标签转换为 map
+func toParams(v interface{}) map[string]interface{} {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var params map[string]interface{}
+	if err := yaml.Unmarshal(data, &params); err != nil {
+		return nil
+	}
+	return params
 }
 
 func LoadConfig(configPath string) (*Config, error) {
@@ -249,11 +441,39 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
+	// 配置中没有的字段保留默认值
+	config := Config{Markup: Markup{Highlight: DefaultHighlight()}}
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// 保留原始的 verification 配置，合并默认 front matter 时未配置的字段不会覆盖内置默认值
+	var raw struct {
+		Verification map[string]interface{} `yaml:"verification"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	config.verificationParams = raw.Verification
+
+	if err := config.validateTaxonomies(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+
+	for id, author := range config.Authors {
+		if author == nil {
+			author = &Author{}
+			config.Authors[id] = author
+		}
+		author.ID = id
+	}
+
+	// 加载项目 data 目录下的数据文件
+	config.Data, err = LoadData(filepath.Join(filepath.Dir(configPath), DataDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data files: %w", err)
+	}
+
 	return &config, nil
 }
 
@@ -269,7 +489,7 @@ func LoadFromTemplate(fs embed.FS, templatePath string, data interface{}) (*Conf
 		return nil, fmt.Errorf("failed to execute config template: %w", err)
 	}
 
-	var cfg Config
+	cfg := Config{Markup: Markup{Highlight: DefaultHighlight()}}
 	if err := yaml.Unmarshal([]byte(buf.String()), &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
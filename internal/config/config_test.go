@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   Highlight
+	}{
+		{"missing", "title: blog\n", DefaultHighlight()},
+		{"partial", "markup:\n  highlight:\n    style: dracula\n", Highlight{Style: "dracula", NoClasses: true, GuessLanguage: true}},
+		{"explicit", "markup:\n  highlight:\n    noClasses: false\n    guessLanguage: false\n", Highlight{Style: "monokai"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if cfg.Markup.Highlight != tt.want {
+				t.Errorf("Markup.Highlight = %+v, want %+v", cfg.Markup.Highlight, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigReservedTaxonomy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"custom", "taxonomies:\n  - name: categories\n", false},
+		{"builtin", "taxonomies:\n  - name: tags\n    title: Tags\n", false},
+		{"posts", "taxonomies:\n  - name: posts\n", true},
+		{"archives", "taxonomies:\n  - name: archives\n", true},
+		{"static", "taxonomies:\n  - name: static\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
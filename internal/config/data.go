@@ -0,0 +1,129 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DataDir 数据文件目录，位于项目根目录下
+const DataDir = "data"
+
+// LoadData 加载数据目录下所有 yaml、json、toml 和 csv 文件，以文件名（不含扩展名）为键，
+// 子目录对应嵌套的 map，例如 data/profile/skills.yaml 对应 .Site.Data.profile.skills。
+// 同名的文件和目录会合并，目录不存在时返回空 map
+func LoadData(dir string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if entry.IsDir() {
+			sub, err := LoadData(path)
+			if err != nil {
+				return nil, err
+			}
+			setData(data, name, sub)
+			continue
+		}
+
+		ext := filepath.Ext(name)
+		value, err := readDataFile(path, ext)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		setData(data, strings.TrimSuffix(name, ext), value)
+	}
+
+	return data, nil
+}
+
+// setData 写入数据，键已存在且两者都是 map 时合并
+func setData(data map[string]interface{}, key string, value interface{}) {
+	existing, ok := data[key].(map[string]interface{})
+	valueMap, valueOK := value.(map[string]interface{})
+	if ok && valueOK {
+		data[key] = MergeParams(existing, valueMap)
+		return
+	}
+	data[key] = value
+}
+
+// readDataFile 按扩展名解析数据文件，不支持的格式返回 nil。
+// csv 文件的第一行为表头，每一行解析为表头到值的 map
+func readDataFile(path, ext string) (interface{}, error) {
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml", ".json", ".toml", ".csv":
+	default:
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file %s: %w", path, err)
+	}
+
+	var value interface{}
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &value)
+	case ".json":
+		err = json.Unmarshal(content, &value)
+	case ".toml":
+		var table map[string]interface{}
+		_, err = toml.Decode(string(content), &table)
+		value = table
+	case ".csv":
+		value, err = parseCSV(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data file %s: %w", path, err)
+	}
+
+	return value, nil
+}
+
+// parseCSV 解析 csv，第一行为表头
+func parseCSV(content []byte) ([]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, field := range header {
+			if i < len(record) {
+				row[field] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
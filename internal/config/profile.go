@@ -0,0 +1,328 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile 站点作者的个人资料，用于首页的个人信息展示
+type Profile struct {
+	Name          string `yaml:"name"`
+	Title         string `yaml:"title"`
+	Avatar        string `yaml:"avatar"`
+	Bio           string `yaml:"bio"`
+	Location      string `yaml:"location"`
+	GitHub        string `yaml:"github"`
+	Status        string `yaml:"status"`
+	WalletAddress string `yaml:"walletAddress"`
+
+	Skills            []Skill             `yaml:"skills"`            // 技术栈
+	Projects          []Project           `yaml:"projects"`          // 项目展示
+	Contributions     []Contribution      `yaml:"contributions"`     // 开源贡献
+	SocialLinks       []SocialLink        `yaml:"socialLinks"`       // 社交链接
+	Education         []Education         `yaml:"education"`         // 教育背景
+	Experience        []Experience        `yaml:"experience"`        // 工作经历
+	Certifications    []Certification     `yaml:"certifications"`    // 证书和资质
+	Contact           Contact             `yaml:"contact"`           // 联系方式
+	RecommendedSeries []RecommendedSeries `yaml:"recommendedSeries"` // 推荐的文章系列
+}
+
+// Skill 技术栈
+type Skill struct {
+	Name       string `yaml:"name"`
+	Level      string `yaml:"level"`
+	Percentage int    `yaml:"percentage"`
+}
+
+// Project 项目展示
+type Project struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	URL         string   `yaml:"url"`     // GitHub 仓库地址
+	Website     string   `yaml:"website"` // 项目网址
+	Image       string   `yaml:"image"`
+	Highlights  []string `yaml:"highlights"`
+	Tags        []string `yaml:"tags"`
+	Stars       int      `yaml:"stars"`
+	Forks       int      `yaml:"forks"`
+
+	// 以下字段由 stars profile sync 从 GitHub 同步
+	Language string    `yaml:"language,omitempty"` // 主要语言
+	Topics   []string  `yaml:"topics,omitempty"`   // 仓库主题
+	PushedAt time.Time `yaml:"pushedAt,omitempty"` // 最后推送时间
+}
+
+// Contribution 开源贡献
+type Contribution struct {
+	Name          string   `yaml:"name"`
+	Description   string   `yaml:"description"`
+	URL           string   `yaml:"url"`
+	Contributions []string `yaml:"contributions"`
+	Impact        string   `yaml:"impact"`
+}
+
+// SocialLink 社交链接
+type SocialLink struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	Icon string `yaml:"icon"`
+}
+
+// Education 教育背景
+type Education struct {
+	School       string   `yaml:"school"`
+	Degree       string   `yaml:"degree"`
+	Major        string   `yaml:"major"`
+	Year         string   `yaml:"year"`
+	Achievements []string `yaml:"achievements"`
+}
+
+// Experience 工作经历
+type Experience struct {
+	Company          string   `yaml:"company"`
+	Position         string   `yaml:"position"`
+	Period           string   `yaml:"period"`
+	Responsibilities []string `yaml:"responsibilities"`
+	Technologies     []string `yaml:"technologies"`
+}
+
+// Certification 证书和资质
+type Certification struct {
+	Name   string `yaml:"name"`
+	Issuer string `yaml:"issuer"`
+	Date   string `yaml:"date"`
+	Icon   string `yaml:"icon"`
+}
+
+// Contact 联系方式
+type Contact struct {
+	Email       string `yaml:"email"`
+	WeChat      string `yaml:"wechat"`
+	Phone       string `yaml:"phone"`
+	Telegram    string `yaml:"telegram"`
+	Twitter     string `yaml:"twitter"`
+	Bilibili    string `yaml:"bilibili"`    // B站用户名/UID
+	Weibo       string `yaml:"weibo"`       // 微博用户名/ID
+	Douyin      string `yaml:"douyin"`      // 抖音号
+	Xiaohongshu string `yaml:"xiaohongshu"` // 小红书号
+	Juejin      string `yaml:"juejin"`      // 掘金用户名
+	Jike        string `yaml:"jike"`        // 即刻用户名
+}
+
+// RecommendedSeries 推荐的文章系列
+type RecommendedSeries struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Icon        string `yaml:"icon"`
+}
+
+// SaveProfile 将个人资料写入配置文件的 author 部分，只替换有变化的字段，
+// 配置文件的其余内容、格式和注释保持不变
+func SaveProfile(configPath string, profile Profile) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse config file: root is not a mapping")
+	}
+	root := doc.Content[0]
+
+	var updated yaml.Node
+	if err := updated.Encode(profile); err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	var edits []lineEdit
+
+	index := mappingIndex(root, "author")
+	author := (*yaml.Node)(nil)
+	if index >= 0 {
+		author = root.Content[index+1]
+	}
+
+	switch {
+	case author == nil:
+		// 配置中没有 author 时追加到文件末尾
+		text, err := encodeEntry("author", &updated, 0)
+		if err != nil {
+			return err
+		}
+		edits = append(edits, lineEdit{len(lines), len(lines), text})
+	case author.Kind != yaml.MappingNode:
+		text, err := encodeEntry("author", &updated, root.Content[index].Column-1)
+		if err != nil {
+			return err
+		}
+		start, end := entryLines(lines, root, index)
+		edits = append(edits, lineEdit{start, end, text})
+	default:
+		var old Profile
+		if err := author.Decode(&old); err != nil {
+			return fmt.Errorf("failed to parse author: %w", err)
+		}
+		var current yaml.Node
+		if err := current.Encode(old); err != nil {
+			return fmt.Errorf("failed to encode profile: %w", err)
+		}
+
+		indent := author.Content[0].Column - 1
+		_, authorEnd := entryLines(lines, author, len(author.Content)-2)
+		appended := lineEdit{start: authorEnd, end: authorEnd}
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			key, value := updated.Content[i].Value, updated.Content[i+1]
+			if sameNode(mappingValue(&current, key), value) {
+				continue
+			}
+
+			j := mappingIndex(author, key)
+			if j >= 0 {
+				keepScalarStyle(author.Content[j+1], value)
+			}
+			text, err := encodeEntry(key, value, indent)
+			if err != nil {
+				return err
+			}
+
+			if j < 0 {
+				appended.lines = append(appended.lines, text...)
+				continue
+			}
+			start, end := entryLines(lines, author, j)
+			edits = append(edits, lineEdit{start, end, text})
+		}
+		if len(appended.lines) > 0 {
+			edits = append(edits, appended)
+		}
+	}
+
+	// 从后往前替换，前面的行号不受影响
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		replaced := append([]string{}, lines[:edit.start]...)
+		replaced = append(replaced, edit.lines...)
+		lines = append(replaced, lines[edit.end:]...)
+	}
+
+	output := strings.Join(lines, "\n")
+	if strings.HasSuffix(string(data), "\n") {
+		output += "\n"
+	}
+	if err := os.WriteFile(configPath, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// lineEdit 将配置文件中 [start, end) 行替换为 lines
+type lineEdit struct {
+	start, end int
+	lines      []string
+}
+
+// entryLines mapping 中第 i 个键值对所占的行范围，不包括末尾的空行和属于下一个键的注释
+func entryLines(lines []string, mapping *yaml.Node, i int) (start, end int) {
+	start = mapping.Content[i].Line - 1
+	end = len(lines)
+	if i+2 < len(mapping.Content) {
+		end = mapping.Content[i+2].Line - 1
+	} else if next := nextLine(lines, start, mapping.Content[i].Column-1); next >= 0 {
+		end = next
+	}
+
+	for end-1 > start {
+		line := strings.TrimSpace(lines[end-1])
+		if line != "" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		end--
+	}
+	return start, end
+}
+
+// nextLine start 之后第一个缩进小于 indent 的非空非注释行，没有时返回 -1
+func nextLine(lines []string, start, indent int) int {
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if len(lines[i])-len(strings.TrimLeft(lines[i], " ")) < indent {
+			return i
+		}
+	}
+	return -1
+}
+
+// encodeEntry 将键值对编码为带缩进的 yaml 行
+func encodeEntry(key string, value *yaml.Node, indent int) ([]string, error) {
+	entry := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	}}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(entry); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	prefix := strings.Repeat(" ", indent)
+	for i := range lines {
+		lines[i] = prefix + lines[i]
+	}
+	return lines, nil
+}
+
+// keepScalarStyle 替换单个值时保留原来的引号和行尾注释
+func keepScalarStyle(old, value *yaml.Node) {
+	if old.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode {
+		value.Style = old.Style
+		value.LineComment = old.LineComment
+	}
+}
+
+// mappingIndex yaml mapping 中 key 所在的位置，不存在时返回 -1
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue 获取 yaml mapping 中 key 对应的值
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(mapping, key); i >= 0 {
+		return mapping.Content[i+1]
+	}
+	return nil
+}
+
+// sameNode 比较两个 yaml 节点的内容是否相同
+func sameNode(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	da, errA := yaml.Marshal(a)
+	db, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
@@ -0,0 +1,90 @@
+package cv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/template/funcs"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+// LayoutFile 主题中的简历打印模板，输出 Markdown
+const LayoutFile = "cv.md"
+
+// Render 使用主题的打印模板将个人资料渲染为 PDF。
+// 模板的输出按 Markdown 解析，标题、段落、列表、分隔线、粗体和链接会转换为对应的排版
+func Render(projectDir string, cfg *config.Config, w io.Writer) error {
+	layout, err := findLayout(projectDir, cfg.Theme)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(LayoutFile).Funcs(template.FuncMap(funcs.DefaultFuncs)).ParseFiles(layout)
+	if err != nil {
+		return fmt.Errorf("failed to parse print layout: %w", err)
+	}
+
+	var markdown bytes.Buffer
+	data := map[string]interface{}{
+		"Site":   cfg,
+		"Author": cfg.Author,
+	}
+	if err := tmpl.Execute(&markdown, data); err != nil {
+		return fmt.Errorf("failed to execute print layout: %w", err)
+	}
+
+	pageSize := cfg.CV.PageSize
+	if pageSize == "" {
+		pageSize = "A4"
+	}
+	pdf := fpdf.New("P", "mm", pageSize, "")
+	pdf.SetTitle(cfg.Author.Name, true)
+	pdf.SetAuthor(cfg.Author.Name, true)
+	pdf.SetCreator("Stars", true)
+
+	if err := loadFonts(pdf, projectDir, cfg); err != nil {
+		return err
+	}
+
+	source := markdown.Bytes()
+	doc := goldmark.New().Parser().Parse(text.NewReader(source))
+	newWriter(pdf, source).render(doc)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return nil
+}
+
+// Save 生成 PDF 并写入文件
+func Save(projectDir string, cfg *config.Config, path string) error {
+	var buf bytes.Buffer
+	if err := Render(projectDir, cfg, &buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// findLayout 查找打印模板，项目 layouts/_default 优先于主题
+func findLayout(projectDir, theme string) (string, error) {
+	candidates := []string{
+		filepath.Join(projectDir, "layouts", "_default", LayoutFile),
+		filepath.Join(projectDir, "themes", theme, "layouts", "_default", LayoutFile),
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("print layout not found: add layouts/_default/%s to the project or theme", LayoutFile)
+}
@@ -0,0 +1,48 @@
+package cv
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/fonts"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// fontFamily PDF 中使用的字体名称
+const fontFamily = "cv"
+
+// loadFonts 嵌入字体，查找顺序：配置中的 cv.font > 项目和主题 static/fonts 下的 .ttf > 系统字体。
+// 都找不到时使用内置的 Go 字体，此时中文无法显示
+func loadFonts(pdf *fpdf.Fpdf, projectDir string, cfg *config.Config) error {
+	regular := fonts.Resolve(projectDir, cfg.CV.Font)
+	if regular == "" {
+		regular = fonts.Find(projectDir, cfg.Theme)
+	}
+	if regular == "" {
+		log.Printf("Warning: no CJK font found for the CV, set cv.font in config.yaml to display Chinese characters")
+		pdf.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+		pdf.AddUTF8FontFromBytes(fontFamily, "B", gobold.TTF)
+		return pdf.Error()
+	}
+
+	bold := fonts.Resolve(projectDir, cfg.CV.BoldFont)
+	if bold == "" {
+		bold = regular
+	}
+
+	for style, path := range map[string]string{"": regular, "B": bold} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read font %s: %w", path, err)
+		}
+		pdf.AddUTF8FontFromBytes(fontFamily, style, data)
+	}
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to load font %s: %w", regular, err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package cv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/yuin/goldmark/ast"
+)
+
+// 排版参数，单位为 mm 和 pt
+const (
+	margin      = 18.0 // 页边距
+	indent      = 5.0  // 列表和引用的缩进
+	bodySize    = 10.0 // 正文字号
+	lineSpacing = 1.5  // 行高与字号的比例
+	ptToMM      = 25.4 / 72
+)
+
+// headingSizes 各级标题的字号
+var headingSizes = map[int]float64{1: 22, 2: 13, 3: 11}
+
+// 文字颜色
+var (
+	textColor  = [3]int{33, 37, 41}
+	mutedColor = [3]int{108, 117, 125}
+	linkColor  = [3]int{13, 110, 253}
+	ruleColor  = [3]int{206, 212, 218}
+)
+
+// writer 将 Markdown 语法树写入 PDF
+type writer struct {
+	pdf    *fpdf.Fpdf
+	source []byte
+	left   float64 // 当前左边距，列表和引用会增加缩进
+	size   float64 // 当前字号
+	bold   bool
+	muted  bool
+	link   string
+}
+
+func newWriter(pdf *fpdf.Fpdf, source []byte) *writer {
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin + 4)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(mutedColor[0], mutedColor[1], mutedColor[2])
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	return &writer{pdf: pdf, source: source, left: margin, size: bodySize}
+}
+
+// lineHeight 当前字号的行高
+func (w *writer) lineHeight() float64 {
+	return w.size * ptToMM * lineSpacing
+}
+
+// render 按块级元素排版
+func (w *writer) render(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.Heading:
+		w.heading(n)
+	case *ast.Paragraph:
+		w.inlines(n)
+		w.pdf.Ln(w.lineHeight())
+		w.pdf.Ln(1.5)
+	case *ast.TextBlock:
+		w.inlines(n)
+		w.pdf.Ln(w.lineHeight())
+	case *ast.List:
+		w.list(n)
+	case *ast.ThematicBreak:
+		w.rule()
+	case *ast.Blockquote:
+		muted := w.muted
+		w.muted = true
+		w.indented(func() { w.children(n) })
+		w.muted = muted
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		lines := n.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			w.write(strings.TrimRight(string(segment.Value(w.source)), "\n"))
+			w.pdf.Ln(w.lineHeight())
+		}
+	case *ast.HTMLBlock:
+		// 打印模板中的 HTML 不会输出
+	default:
+		w.children(n)
+	}
+}
+
+func (w *writer) children(node ast.Node) {
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		w.render(child)
+	}
+}
+
+// heading 标题，一级标题为姓名，二级标题为模块名，下方画分隔线
+func (w *writer) heading(n *ast.Heading) {
+	size, ok := headingSizes[n.Level]
+	if !ok {
+		size = bodySize
+	}
+
+	if n.Level <= 2 && w.pdf.GetY() > margin {
+		w.pdf.Ln(3)
+	}
+
+	bold := w.bold
+	w.size, w.bold = size, true
+	w.inlines(n)
+	w.pdf.Ln(w.lineHeight())
+	w.size, w.bold = bodySize, bold
+
+	if n.Level == 2 {
+		w.rule()
+	} else {
+		w.pdf.Ln(1)
+	}
+}
+
+// list 列表项前输出符号，内容使用悬挂缩进
+func (w *writer) list(n *ast.List) {
+	number := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "•"
+		if n.IsOrdered() {
+			marker = fmt.Sprintf("%d.", number)
+			number++
+		}
+
+		w.pdf.SetX(w.left)
+		w.style()
+		w.pdf.CellFormat(indent, w.lineHeight(), marker, "", 0, "L", false, 0, "")
+		w.indented(func() { w.children(item) })
+	}
+	w.pdf.Ln(1.5)
+}
+
+// indented 增加左边距后排版
+func (w *writer) indented(fn func()) {
+	left := w.left
+	w.left += indent
+	w.pdf.SetLeftMargin(w.left)
+	fn()
+	w.left = left
+	w.pdf.SetLeftMargin(w.left)
+	w.pdf.SetX(w.left)
+}
+
+// rule 水平分隔线
+func (w *writer) rule() {
+	pageWidth, _ := w.pdf.GetPageSize()
+	y := w.pdf.GetY() + 1
+	w.pdf.SetDrawColor(ruleColor[0], ruleColor[1], ruleColor[2])
+	w.pdf.SetLineWidth(0.2)
+	w.pdf.Line(w.left, y, pageWidth-margin, y)
+	w.pdf.Ln(3)
+}
+
+// inlines 行内元素：**粗体**、*次要文字*（灰色）和链接
+func (w *writer) inlines(node ast.Node) {
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.Text:
+			w.write(string(n.Segment.Value(w.source)))
+			if n.HardLineBreak() {
+				w.pdf.Ln(w.lineHeight())
+			} else if n.SoftLineBreak() {
+				w.write(" ")
+			}
+		case *ast.String:
+			w.write(string(n.Value))
+		case *ast.Emphasis:
+			bold, muted := w.bold, w.muted
+			if n.Level >= 2 {
+				w.bold = true
+			} else {
+				w.muted = true
+			}
+			w.inlines(n)
+			w.bold, w.muted = bold, muted
+		case *ast.Link:
+			link := w.link
+			w.link = string(n.Destination)
+			w.inlines(n)
+			w.link = link
+		case *ast.AutoLink:
+			link := w.link
+			w.link = string(n.URL(w.source))
+			w.write(string(n.Label(w.source)))
+			w.link = link
+		case *ast.RawHTML, *ast.Image:
+			// 不输出
+		default:
+			w.inlines(n)
+		}
+	}
+}
+
+// style 按当前状态设置字体和颜色
+func (w *writer) style() {
+	style := ""
+	if w.bold {
+		style = "B"
+	}
+	w.pdf.SetFont(fontFamily, style, w.size)
+
+	color := textColor
+	switch {
+	case w.link != "":
+		color = linkColor
+	case w.muted:
+		color = mutedColor
+	}
+	w.pdf.SetTextColor(color[0], color[1], color[2])
+}
+
+// write 输出文字，超出行宽时自动换行
+func (w *writer) write(s string) {
+	if s == "" {
+		return
+	}
+	w.style()
+	if w.link != "" {
+		w.pdf.WriteLinkString(w.lineHeight(), s, w.link)
+		return
+	}
+	w.pdf.Write(w.lineHeight(), s)
+}
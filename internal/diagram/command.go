@@ -0,0 +1,43 @@
+package diagram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// 使用本地安装的 d2 和 Graphviz 命令渲染，未安装时代码块按普通代码高亮显示。
+// 不内置 Go 版本的渲染器，避免提高 Go 版本要求和增大程序体积
+func init() {
+	if _, err := exec.LookPath("d2"); err == nil {
+		Register("d2", &command{name: "d2", args: []string{"--theme=200", "--pad=20", "-", "-"}})
+	}
+	if _, err := exec.LookPath("dot"); err == nil {
+		dot := &command{name: "dot", args: []string{"-Tsvg"}}
+		Register("dot", dot)
+		Register("graphviz", dot)
+	}
+}
+
+// command 调用命令行工具渲染图表，从标准输入读取源码，向标准输出写入 SVG
+type command struct {
+	name string
+	args []string
+}
+
+func (c *command) RenderSVG(ctx context.Context, source []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Stdin = bytes.NewReader(source)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", c.name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+	return stdout.Bytes(), nil
+}
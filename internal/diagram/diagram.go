@@ -0,0 +1,136 @@
+// Package diagram 在构建时将 mermaid、d2、dot 等图表代码块渲染为 SVG
+package diagram
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Renderer 将图表源码渲染为 SVG
+type Renderer interface {
+	RenderSVG(ctx context.Context, source []byte) ([]byte, error)
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]Renderer)
+)
+
+// Register 注册指定语言的图表渲染器，已存在时覆盖
+func Register(lang string, r Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[lang] = r
+}
+
+// lookup 查找指定语言的渲染器
+func lookup(lang string) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	r, ok := renderers[lang]
+	return r, ok
+}
+
+// clientSide 没有本地渲染器时交给浏览器渲染的语言
+var clientSide = map[string]bool{
+	"mermaid": true,
+}
+
+// Diagrams 渲染图表并按内容哈希缓存结果
+type Diagrams struct {
+	dir   string // 磁盘缓存目录，为空时只使用内存缓存
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// New 创建图表渲染器，cacheDir 为 SVG 的磁盘缓存目录
+func New(cacheDir string) *Diagrams {
+	return &Diagrams{
+		dir:   cacheDir,
+		cache: make(map[string]string),
+	}
+}
+
+// Supports 判断是否能处理指定语言的代码块
+func (d *Diagrams) Supports(lang string) bool {
+	_, ok := lookup(lang)
+	return ok || clientSide[lang]
+}
+
+// Render 将图表代码块渲染为 HTML
+func (d *Diagrams) Render(lang string, source []byte) (string, error) {
+	r, ok := lookup(lang)
+	if !ok {
+		if clientSide[lang] {
+			// 由页面中的 mermaid 脚本渲染
+			return `<pre class="` + lang + `">` + html.EscapeString(string(source)) + "</pre>", nil
+		}
+		return "", fmt.Errorf("unsupported diagram language: %s", lang)
+	}
+
+	svg, err := d.svg(lang, source, r)
+	if err != nil {
+		return "", err
+	}
+	return `<div class="diagram diagram-` + lang + `">` + svg + "</div>", nil
+}
+
+// svg 渲染 SVG，优先读取缓存
+func (d *Diagrams) svg(lang string, source []byte, r Renderer) (string, error) {
+	sum := sha256.Sum256(append([]byte(lang+"\x00"), source...))
+	key := hex.EncodeToString(sum[:])
+
+	d.mu.Lock()
+	svg, ok := d.cache[key]
+	d.mu.Unlock()
+	if ok {
+		return svg, nil
+	}
+
+	path := filepath.Join(d.dir, key+".svg")
+	if d.dir != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			svg = string(data)
+			d.store(key, svg)
+			return svg, nil
+		}
+	}
+
+	out, err := r.RenderSVG(context.Background(), source)
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s diagram: %w", lang, err)
+	}
+	svg = string(stripProlog(out))
+
+	if d.dir != "" {
+		if err := os.MkdirAll(d.dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create diagram cache directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(svg), 0644); err != nil {
+			return "", fmt.Errorf("failed to write diagram cache: %w", err)
+		}
+	}
+	d.store(key, svg)
+	return svg, nil
+}
+
+func (d *Diagrams) store(key, svg string) {
+	d.mu.Lock()
+	d.cache[key] = svg
+	d.mu.Unlock()
+}
+
+// stripProlog 去掉 XML 声明和 DOCTYPE，使 SVG 可以直接嵌入 HTML
+func stripProlog(svg []byte) []byte {
+	if i := bytes.Index(svg, []byte("<svg")); i > 0 {
+		return svg[i:]
+	}
+	return svg
+}
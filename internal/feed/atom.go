@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   *atomPerson `xml:"author,omitempty"`
+	Icon     string      `xml:"icon,omitempty"`
+	Gen      string      `xml:"generator"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+func writeAtom(w io.Writer, f *Feed, self string) error {
+	doc := atomFeed{
+		XMLNS:    "http://www.w3.org/2005/Atom",
+		Lang:     f.Language,
+		ID:       self,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Gen: "Stars",
+	}
+	if f.Author != nil {
+		doc.Author = &atomPerson{Name: f.Author.Name, URI: f.Author.URL}
+		doc.Icon = f.Author.Avatar
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Links:   []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Updated: atomTime(item.Updated, f.Updated),
+		}
+		if !item.Published.IsZero() {
+			entry.Published = item.Published.Format(time.RFC3339)
+		}
+		for _, a := range item.Authors {
+			entry.Authors = append(entry.Authors, atomPerson{Name: a.Name, URI: a.URL})
+		}
+		for _, c := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		if e := item.Enclosure; e != nil {
+			entry.Links = append(entry.Links, atomLink{Href: e.URL, Rel: "enclosure", Type: e.Type, Length: e.Length})
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		if item.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: item.Content}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return encodeXML(w, doc)
+}
+
+// atomTime Atom 要求每个条目都有 updated，文章没有日期时使用订阅源的更新时间
+func atomTime(t, fallback time.Time) string {
+	if t.IsZero() {
+		t = fallback
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,309 @@
+// Package feed 生成 RSS 2.0、Atom 1.0 和 JSON Feed 1.1 格式的订阅源，以及带 iTunes 扩展的播客订阅源
+package feed
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/post"
+)
+
+const (
+	DefaultLimit     = 20 // 站点订阅源默认的文章数量
+	DefaultTermLimit = 10 // 分类订阅源默认的文章数量
+)
+
+// Format 订阅源格式
+type Format struct {
+	Name     string // 配置中使用的名称
+	FileName string // 输出的文件名
+	MIME     string
+	write    func(w io.Writer, f *Feed, self string) error // self 为订阅源自身的地址
+}
+
+// Formats 支持的格式，按输出顺序排列
+var Formats = []Format{
+	{Name: "rss", FileName: "feed.xml", MIME: "application/rss+xml", write: writeRSS},
+	{Name: "atom", FileName: "atom.xml", MIME: "application/atom+xml", write: writeAtom},
+	{Name: "json", FileName: "feed.json", MIME: "application/feed+json", write: writeJSON},
+}
+
+// PodcastFormat 播客订阅源，使用带 iTunes 扩展的 RSS 2.0
+var PodcastFormat = Format{Name: "podcast", FileName: "podcast.xml", MIME: "application/rss+xml", write: writeRSS}
+
+// FormatByFile 根据文件名查找格式
+func FormatByFile(name string) (Format, bool) {
+	for _, format := range Formats {
+		if format.FileName == name {
+			return format, true
+		}
+	}
+	return Format{}, false
+}
+
+// Person 作者
+type Person struct {
+	Name   string
+	URL    string
+	Avatar string
+}
+
+// Item 订阅源中的一篇文章
+type Item struct {
+	ID         string // 唯一标识，使用文章的完整地址
+	Title      string
+	Link       string
+	Summary    string
+	Content    string // 完整的 HTML 内容，未启用 fullContent 时为空
+	Published  time.Time
+	Updated    time.Time
+	Categories []string
+	Authors    []Person
+	Enclosure  *Enclosure  // 音频文章的音频文件
+	Audio      *post.Audio // 音频文章的节目信息
+}
+
+// Enclosure 文章附带的媒体文件
+type Enclosure struct {
+	URL    string
+	Length int64
+	Type   string
+}
+
+// Podcast 播客订阅源的频道信息
+type Podcast struct {
+	Author   string
+	Email    string
+	Image    string // 封面的完整地址
+	Category string
+	Explicit bool
+	Type     string
+}
+
+// Feed 与格式无关的订阅源
+type Feed struct {
+	Title       string
+	Description string
+	Link        string // 订阅源对应页面的完整地址
+	Path        string // 订阅源所在的目录，如 / 或 /tags/go/，用于生成订阅源自身的地址
+	Language    string
+	Updated     time.Time
+	Author      *Person
+	Items       []*Item
+	Podcast     *Podcast // 播客订阅源的频道信息，普通订阅源为空
+
+	baseURL string
+}
+
+// Options 生成订阅源的参数
+type Options struct {
+	Title       string // 为空时使用站点标题
+	Description string // 为空时使用站点描述
+	Path        string // 订阅源对应的页面路径，如 / 或 /tags/go
+	Limit       int    // 文章数量，0 使用 DefaultLimit，负数表示全部
+}
+
+// TermLimit 分类订阅源的文章数量
+func TermLimit(feeds config.Feeds) int {
+	if feeds.TermLimit == 0 {
+		return DefaultTermLimit
+	}
+	return feeds.TermLimit
+}
+
+// New 根据文章生成订阅源，文章按发布时间从新到旧排列
+func New(site *config.Config, posts []*post.Post, opts Options) *Feed {
+	sorted := make([]*post.Post, len(posts))
+	copy(sorted, posts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.After(sorted[j].Date)
+	})
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	path := "/" + strings.Trim(opts.Path, "/")
+	f := &Feed{
+		Title:       opts.Title,
+		Description: opts.Description,
+		Link:        config.AbsURL(site.BaseURL, path),
+		Path:        strings.TrimSuffix(path, "/") + "/",
+		Language:    site.LanguageCode(),
+		baseURL:     site.BaseURL,
+	}
+	if f.Title == "" {
+		f.Title = site.Title
+	}
+	if f.Description == "" {
+		f.Description = site.Description
+	}
+	if site.Author.Name != "" {
+		f.Author = &Person{
+			Name:   site.Author.Name,
+			URL:    config.AbsURL(site.BaseURL, "/"),
+			Avatar: avatarURL(site, site.Author.Avatar),
+		}
+	}
+
+	for _, p := range sorted {
+		item := newItem(site, p)
+		if item.Updated.After(f.Updated) {
+			f.Updated = item.Updated
+		}
+		f.Items = append(f.Items, item)
+	}
+	if f.Updated.IsZero() {
+		f.Updated = time.Now()
+	}
+	return f
+}
+
+func newItem(site *config.Config, p *post.Post) *Item {
+	link := config.AbsURL(site.BaseURL, p.URL())
+	item := &Item{
+		ID:         link,
+		Title:      p.Title,
+		Link:       link,
+		Summary:    p.Description,
+		Published:  p.Date,
+		Updated:    p.Lastmod,
+		Categories: p.Tags,
+	}
+	if item.Updated.IsZero() {
+		item.Updated = p.Date
+	}
+	if site.Feeds.FullContent {
+		item.Content = absoluteURLs(string(p.Content), site.BaseURL)
+	}
+	if p.Audio != nil {
+		item.Audio = p.Audio
+		item.Enclosure = &Enclosure{
+			URL:    config.AbsURL(site.BaseURL, p.Audio.File),
+			Length: p.Audio.Size,
+			Type:   p.Audio.MIME(),
+		}
+	}
+
+	for _, a := range p.GetAuthors() {
+		item.Authors = append(item.Authors, Person{
+			Name:   a.Name,
+			URL:    config.AbsURL(site.BaseURL, a.URL()),
+			Avatar: avatarURL(site, a.Avatar),
+		})
+	}
+	if len(item.Authors) == 0 && site.Author.Name != "" {
+		item.Authors = []Person{{Name: site.Author.Name, URL: config.AbsURL(site.BaseURL, "/")}}
+	}
+	return item
+}
+
+// NewPodcast 生成播客订阅源，包含所有音频文章
+func NewPodcast(site *config.Config, posts []*post.Post) *Feed {
+	var episodes []*post.Post
+	for _, p := range posts {
+		if p.Audio != nil {
+			episodes = append(episodes, p)
+		}
+	}
+
+	cfg := site.Podcast
+	f := New(site, episodes, Options{
+		Title:       cfg.Title,
+		Description: cfg.Description,
+		Limit:       -1,
+	})
+	f.Podcast = &Podcast{
+		Author:   cfg.Author,
+		Email:    cfg.Email,
+		Category: cfg.Category,
+		Explicit: cfg.Explicit,
+		Type:     cfg.Type,
+	}
+	if f.Podcast.Author == "" {
+		f.Podcast.Author = site.Author.Name
+	}
+	if cfg.Image != "" {
+		f.Podcast.Image = config.AbsURL(site.BaseURL, cfg.Image)
+	} else if f.Author != nil {
+		f.Podcast.Image = f.Author.Avatar
+	}
+	if f.Podcast.Type == "" {
+		f.Podcast.Type = "episodic"
+	}
+	return f
+}
+
+// URL 订阅源指定格式的完整地址
+func (f *Feed) URL(format Format) string {
+	return config.AbsURL(f.baseURL, f.Path+format.FileName)
+}
+
+// Write 以指定格式输出订阅源
+func (f *Feed) Write(w io.Writer, format Format) error {
+	return format.write(w, f, f.URL(format))
+}
+
+// Save 将站点配置中启用的各个格式写入 dir
+func (f *Feed) Save(dir string, feeds config.Feeds) error {
+	for _, format := range Formats {
+		if !feeds.Enabled(format.Name) {
+			continue
+		}
+		if err := f.WriteFile(dir, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile 以指定格式将订阅源写入 dir 下的 format.FileName
+func (f *Feed) WriteFile(dir string, format Format) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf, format); err != nil {
+		return fmt.Errorf("failed to generate %s feed: %w", format.Name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, format.FileName), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", format.FileName, err)
+	}
+	return nil
+}
+
+// avatarURL 头像的完整地址，构建时模板中的头像可能已被改写为相对路径
+func avatarURL(site *config.Config, avatar string) string {
+	if avatar == "" {
+		return ""
+	}
+	for strings.HasPrefix(avatar, "../") || strings.HasPrefix(avatar, "./") {
+		avatar = strings.TrimPrefix(strings.TrimPrefix(avatar, "../"), "./")
+	}
+	return config.AbsURL(site.BaseURL, "/"+strings.TrimPrefix(avatar, "/"))
+}
+
+// rootRelative 匹配 HTML 中以 / 开头的站内地址，不包括 // 开头的地址
+var rootRelative = regexp.MustCompile(`(\s(?:src|href|poster)=")/([^/"])`)
+
+// absoluteURLs 将文章内容中的站内地址替换为完整地址，阅读器中才能正确显示图片和链接
+func absoluteURLs(content, baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	if base == "" || !strings.Contains(base, "://") {
+		return content
+	}
+	return rootRelative.ReplaceAllString(content, "${1}"+base+"/${2}")
+}
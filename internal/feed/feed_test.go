@@ -0,0 +1,184 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/post"
+)
+
+func testSite() *config.Config {
+	return &config.Config{
+		Title:   "Stars",
+		BaseURL: "https://example.com/",
+		Author:  config.Profile{Name: "Alice"},
+	}
+}
+
+func testPost(slug string, audio *post.Audio) *post.Post {
+	return &post.Post{
+		Title:   "Hello",
+		Slug:    slug,
+		Section: post.PostsSection,
+		Date:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Audio:   audio,
+	}
+}
+
+func TestPermalinks(t *testing.T) {
+	const want = "https://example.com/posts/hello-world"
+
+	tests := []struct {
+		format Format
+		links  func(t *testing.T, data []byte) (id, link string)
+	}{
+		{Formats[0], func(t *testing.T, data []byte) (string, string) {
+			var doc struct {
+				Items []struct {
+					Link string `xml:"link"`
+					GUID string `xml:"guid"`
+				} `xml:"channel>item"`
+			}
+			if err := xml.Unmarshal(data, &doc); err != nil || len(doc.Items) != 1 {
+				t.Fatalf("invalid rss: %v\n%s", err, data)
+			}
+			return doc.Items[0].GUID, doc.Items[0].Link
+		}},
+		{Formats[1], func(t *testing.T, data []byte) (string, string) {
+			var doc struct {
+				Entries []struct {
+					ID    string `xml:"id"`
+					Links []struct {
+						Href string `xml:"href,attr"`
+						Rel  string `xml:"rel,attr"`
+					} `xml:"link"`
+				} `xml:"entry"`
+			}
+			if err := xml.Unmarshal(data, &doc); err != nil || len(doc.Entries) != 1 || len(doc.Entries[0].Links) == 0 {
+				t.Fatalf("invalid atom: %v\n%s", err, data)
+			}
+			return doc.Entries[0].ID, doc.Entries[0].Links[0].Href
+		}},
+		{Formats[2], func(t *testing.T, data []byte) (string, string) {
+			var doc struct {
+				Items []struct {
+					ID  string `json:"id"`
+					URL string `json:"url"`
+				} `json:"items"`
+			}
+			if err := json.Unmarshal(data, &doc); err != nil || len(doc.Items) != 1 {
+				t.Fatalf("invalid json feed: %v\n%s", err, data)
+			}
+			return doc.Items[0].ID, doc.Items[0].URL
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.Name, func(t *testing.T) {
+			f := New(testSite(), []*post.Post{testPost("hello-world", nil)}, Options{})
+			var buf bytes.Buffer
+			if err := f.Write(&buf, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			id, link := tt.links(t, buf.Bytes())
+			if id != want {
+				t.Errorf("id = %q, want %q", id, want)
+			}
+			if link != want {
+				t.Errorf("link = %q, want %q", link, want)
+			}
+		})
+	}
+}
+
+func TestPodcast(t *testing.T) {
+	const itunes = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+
+	site := testSite()
+	site.Podcast = config.Podcast{
+		Enabled:  true,
+		Image:    "/static/images/cover.jpg",
+		Email:    "alice@example.com",
+		Category: "Technology",
+	}
+	episode := testPost("episode-3", &post.Audio{
+		File:     "/static/audio/episode-3.mp3",
+		Duration: "1:02:03",
+		Size:     1234,
+		Episode:  3,
+		Season:   1,
+	})
+	f := NewPodcast(site, []*post.Post{episode, testPost("article", nil)})
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf, PodcastFormat); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Channel struct {
+			Author   string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+			Explicit string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
+			Type     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd type"`
+			Owner    struct {
+				Email string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd email"`
+			} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd owner"`
+			Image struct {
+				Href string `xml:"href,attr"`
+			} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+			Category struct {
+				Text string `xml:"text,attr"`
+			} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+			Items []struct {
+				Link      string `xml:"link"`
+				Enclosure struct {
+					URL    string `xml:"url,attr"`
+					Length string `xml:"length,attr"`
+					Type   string `xml:"type,attr"`
+				} `xml:"enclosure"`
+				Duration    string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+				Episode     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+				Season      string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season"`
+				EpisodeType string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`xmlns:itunes="`+itunes+`"`)) {
+		t.Errorf("missing itunes namespace:\n%s", buf.String())
+	}
+	if len(doc.Channel.Items) != 1 {
+		t.Fatalf("got %d items, want only the audio post", len(doc.Channel.Items))
+	}
+	ch, item := doc.Channel, doc.Channel.Items[0]
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"itunes:author", ch.Author, "Alice"},
+		{"itunes:explicit", ch.Explicit, "false"},
+		{"itunes:type", ch.Type, "episodic"},
+		{"itunes:owner/email", ch.Owner.Email, "alice@example.com"},
+		{"itunes:image", ch.Image.Href, "https://example.com/static/images/cover.jpg"},
+		{"itunes:category", ch.Category.Text, "Technology"},
+		{"link", item.Link, "https://example.com/posts/episode-3"},
+		{"enclosure url", item.Enclosure.URL, "https://example.com/static/audio/episode-3.mp3"},
+		{"enclosure length", item.Enclosure.Length, "1234"},
+		{"enclosure type", item.Enclosure.Type, "audio/mpeg"},
+		{"itunes:duration", item.Duration, "3723"},
+		{"itunes:episode", item.Episode, "3"},
+		{"itunes:season", item.Season, "1"},
+		{"itunes:episodeType", item.EpisodeType, "full"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
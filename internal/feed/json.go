@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonFeed JSON Feed 1.1，https://www.jsonfeed.org/version/1.1/
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url,omitempty"`
+	FeedURL     string       `json:"feed_url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Icon        string       `json:"icon,omitempty"`
+	Language    string       `json:"language,omitempty"`
+	Authors     []jsonAuthor `json:"authors,omitempty"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	ContentHTML   string       `json:"content_html,omitempty"`
+	ContentText   string       `json:"content_text,omitempty"`
+	Summary       string       `json:"summary,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	DateModified  string       `json:"date_modified,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	Attachments   []jsonAttach `json:"attachments,omitempty"`
+}
+
+type jsonAttach struct {
+	URL      string `json:"url"`
+	MIMEType string `json:"mime_type"`
+	Size     int64  `json:"size_in_bytes,omitempty"`
+	Duration int    `json:"duration_in_seconds,omitempty"`
+}
+
+func writeJSON(w io.Writer, f *Feed, self string) error {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     self,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       []jsonItem{},
+	}
+	if f.Author != nil {
+		doc.Authors = []jsonAuthor{{Name: f.Author.Name, URL: f.Author.URL, Avatar: f.Author.Avatar}}
+		doc.Icon = f.Author.Avatar
+	}
+
+	for _, item := range f.Items {
+		ji := jsonItem{
+			ID:          item.ID,
+			URL:         item.Link,
+			Title:       item.Title,
+			ContentHTML: item.Content,
+			Summary:     item.Summary,
+			Tags:        item.Categories,
+		}
+		// content_html 和 content_text 至少需要一个
+		if ji.ContentHTML == "" {
+			ji.ContentText = item.Summary
+		}
+		if !item.Published.IsZero() {
+			ji.DatePublished = item.Published.Format(time.RFC3339)
+		}
+		if !item.Updated.IsZero() && !item.Updated.Equal(item.Published) {
+			ji.DateModified = item.Updated.Format(time.RFC3339)
+		}
+		if e := item.Enclosure; e != nil {
+			attach := jsonAttach{URL: e.URL, MIMEType: e.Type, Size: e.Length}
+			if item.Audio != nil {
+				attach.Duration = item.Audio.Seconds()
+			}
+			ji.Attachments = append(ji.Attachments, attach)
+		}
+		for _, a := range item.Authors {
+			ji.Authors = append(ji.Authors, jsonAuthor{Name: a.Name, URL: a.URL, Avatar: a.Avatar})
+		}
+		doc.Items = append(doc.Items, ji)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
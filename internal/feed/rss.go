@@ -0,0 +1,171 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+type rss struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XMLNSAtom    string     `xml:"xmlns:atom,attr"`
+	XMLNSContent string     `xml:"xmlns:content,attr"`
+	XMLNSDC      string     `xml:"xmlns:dc,attr"`
+	XMLNSItunes  string     `xml:"xmlns:itunes,attr,omitempty"`
+	Channel      rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	Generator     string    `xml:"generator"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Image         *rssImage `xml:"image,omitempty"`
+
+	// 播客订阅源的 iTunes 扩展
+	ItunesAuthor   string          `xml:"itunes:author,omitempty"`
+	ItunesSummary  string          `xml:"itunes:summary,omitempty"`
+	ItunesOwner    *itunesOwner    `xml:"itunes:owner,omitempty"`
+	ItunesImage    *itunesImage    `xml:"itunes:image,omitempty"`
+	ItunesCategory *itunesCategory `xml:"itunes:category,omitempty"`
+	ItunesExplicit string          `xml:"itunes:explicit,omitempty"`
+	ItunesType     string          `xml:"itunes:type,omitempty"`
+
+	Items []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type itunesOwner struct {
+	Name  string `xml:"itunes:name"`
+	Email string `xml:"itunes:email,omitempty"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type itunesCategory struct {
+	Text string `xml:"text,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Description string        `xml:"description"`
+	Content     *cdata        `xml:"content:encoded,omitempty"`
+	Creators    []string      `xml:"dc:creator"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+
+	ItunesDuration    int    `xml:"itunes:duration,omitempty"`
+	ItunesEpisode     int    `xml:"itunes:episode,omitempty"`
+	ItunesSeason      int    `xml:"itunes:season,omitempty"`
+	ItunesEpisodeType string `xml:"itunes:episodeType,omitempty"`
+	ItunesExplicit    string `xml:"itunes:explicit,omitempty"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+func writeRSS(w io.Writer, f *Feed, self string) error {
+	doc := rss{
+		Version:      "2.0",
+		XMLNSAtom:    "http://www.w3.org/2005/Atom",
+		XMLNSContent: "http://purl.org/rss/1.0/modules/content/",
+		XMLNSDC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			Language:      f.Language,
+			Generator:     "Stars",
+			LastBuildDate: f.Updated.Format(time.RFC1123Z),
+			AtomLink:      atomLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if p := f.Podcast; p != nil {
+		doc.XMLNSItunes = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+		ch := &doc.Channel
+		ch.ItunesAuthor = p.Author
+		ch.ItunesSummary = f.Description
+		ch.ItunesOwner = &itunesOwner{Name: p.Author, Email: p.Email}
+		ch.ItunesExplicit = strconv.FormatBool(p.Explicit)
+		ch.ItunesType = p.Type
+		if p.Image != "" {
+			ch.Image = &rssImage{URL: p.Image, Title: f.Title, Link: f.Link}
+			ch.ItunesImage = &itunesImage{Href: p.Image}
+		}
+		if p.Category != "" {
+			ch.ItunesCategory = &itunesCategory{Text: p.Category}
+		}
+	}
+
+	for _, item := range f.Items {
+		ri := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: item.ID},
+			Description: item.Summary,
+			Categories:  item.Categories,
+		}
+		if !item.Published.IsZero() {
+			ri.PubDate = item.Published.Format(time.RFC1123Z)
+		}
+		if item.Content != "" {
+			ri.Content = &cdata{item.Content}
+		}
+		for _, a := range item.Authors {
+			ri.Creators = append(ri.Creators, a.Name)
+		}
+		if e := item.Enclosure; e != nil {
+			ri.Enclosure = &rssEnclosure{URL: e.URL, Length: e.Length, Type: e.Type}
+		}
+		if a := item.Audio; a != nil && f.Podcast != nil {
+			ri.ItunesDuration = a.Seconds()
+			ri.ItunesEpisode = a.Episode
+			ri.ItunesSeason = a.Season
+			ri.ItunesEpisodeType = "full"
+			ri.ItunesExplicit = strconv.FormatBool(a.Explicit)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, ri)
+	}
+
+	return encodeXML(w, doc)
+}
+
+func encodeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,60 @@
+// Package fonts 查找包含中文字形的 TrueType 字体，供简历 PDF 和分享图片使用
+package fonts
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/flopp/go-findfont"
+)
+
+// systemFonts 常见系统中包含中文字形的 TrueType 字体，按顺序查找
+var systemFonts = []string{
+	"NotoSansSC-Regular.ttf",
+	"SourceHanSansSC-Regular.ttf",
+	"DroidSansFallbackFull.ttf",
+	"DroidSansFallback.ttf",
+	"Arial Unicode.ttf",
+	"simhei.ttf",
+	"simkai.ttf",
+}
+
+// Resolve 配置中的字体路径，相对路径基于项目目录
+func Resolve(projectDir, path string) string {
+	if path == "" {
+		return ""
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectDir, path)
+	}
+	return path
+}
+
+// Find 自动查找字体，查找顺序：项目和主题 static/fonts 下的 .ttf > 系统字体。
+// 只使用 .ttf 文件，fpdf 不支持 OpenType 和字体集合；找不到时返回空字符串
+func Find(projectDir, theme string) string {
+	dirs := []string{
+		filepath.Join(projectDir, "static", "fonts"),
+		filepath.Join(projectDir, "themes", theme, "static", "fonts"),
+	}
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.ttf"))
+		for _, match := range matches {
+			// 优先使用常规字重，粗体需要单独指定
+			if !strings.Contains(strings.ToLower(filepath.Base(match)), "bold") {
+				return match
+			}
+		}
+		if len(matches) > 0 {
+			return matches[0]
+		}
+	}
+
+	for _, name := range systemFonts {
+		path, err := findfont.Find(name)
+		if err == nil && strings.EqualFold(filepath.Ext(path), ".ttf") {
+			return path
+		}
+	}
+	return ""
+}
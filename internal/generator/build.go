@@ -1,17 +1,28 @@
 package generator
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jiangjiax/stars/internal/asset"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/cv"
+	"github.com/jiangjiax/stars/internal/feed"
+	"github.com/jiangjiax/stars/internal/github"
+	"github.com/jiangjiax/stars/internal/markup"
+	"github.com/jiangjiax/stars/internal/ogimage"
 	"github.com/jiangjiax/stars/internal/post"
-	"github.com/jiangjiax/stars/internal/rss"
+	"github.com/jiangjiax/stars/internal/resume"
 	"github.com/jiangjiax/stars/internal/sitemap"
 	"github.com/jiangjiax/stars/internal/template"
+	"github.com/jiangjiax/stars/internal/template/funcs"
 )
 
 // Build generates the static website from the project
@@ -24,15 +35,41 @@ func (p *Project) Build() error {
 		return fmt.Errorf("failed to build assets: %w", err)
 	}
 
+	// 为所有静态文件计算指纹，模板中的 AssetPath 和 Integrity 需要用到
+	if err := assets.Fingerprint(assets.StaticDirs()...); err != nil {
+		return err
+	}
+
+	// 构建时生成的代码高亮样式与静态文件一起计算指纹
+	var highlightCSS []byte
+	if !p.Site.Markup.Highlight.NoClasses {
+		var css bytes.Buffer
+		if err := markup.WriteHighlightCSS(&css, p.Site.Markup.Highlight); err != nil {
+			return fmt.Errorf("failed to generate highlight css: %w", err)
+		}
+		highlightCSS = css.Bytes()
+		assets.FingerprintFiles(map[string][]byte{"css/chroma.css": highlightCSS})
+	}
+
 	engine, err := template.New(p.Path, p.Site, true, assets)
 	if err != nil {
 		return fmt.Errorf("failed to initialize template engine: %w", err)
 	}
 
+	// 内容加载器使用项目的短代码、渲染钩子和图片处理器，
+	// 模板中的 imageResize、srcset 使用同一个图片处理器
+	loader := post.NewLoader(p.Path, p.Site)
+	funcs.SetImageProcessor(loader.Images())
+
 	builder := &Builder{
 		project:   p,
 		publicDir: filepath.Join(p.Path, "public"),
 		engine:    engine,
+		assets:    assets,
+		loader:    loader,
+		highlight: highlightCSS,
+		store:     post.New(),
+		sitemap:   sitemap.New(p.Site),
 	}
 	return builder.Build()
 }
@@ -42,6 +79,11 @@ type Builder struct {
 	project   *Project
 	publicDir string
 	engine    *template.Engine
+	assets    *asset.Pipeline
+	loader    *post.Loader
+	store     *post.Store      // all posts, filled by parsePosts
+	sitemap   *sitemap.Sitemap // pages are added as they are rendered
+	highlight []byte           // chroma.css, nil when code blocks use inline styles
 }
 
 // Build executes the full build process
@@ -52,14 +94,26 @@ func (b *Builder) Build() error {
 	}{
 		{"Initialize templates", b.project.initBuildTemplates},
 		{"Clean public directory", b.cleanPublicDir},
+		{"Sync GitHub projects", b.syncGitHubProjects},
+		// 渲染页面时会改写个人资料中的图片路径，简历需要在渲染之前生成
+		{"Generate resume", b.generateResume},
+		{"Generate CV", b.generateCV},
 		{"Parse posts", b.parsePosts},
+		{"Parse sections and pages", b.parseSections},
+		{"Resolve wiki links", b.resolveLinks},
+		{"Generate share images", b.generateShareImages},
 		{"Generate posts", b.generatePosts},
+		{"Generate sections and pages", b.generateSections},
 		{"Generate index page", b.generateIndex},
 		{"Generate list page", b.generateList},
 		{"Copy static files", b.copyStaticFiles},
+		{"Generate highlight CSS", b.generateHighlightCSS},
 		{"Generate paginated lists", b.generatePaginatedLists},
 		{"Generate taxonomy pages", b.generateTaxonomyPages},
 		{"Generate tags page", b.generateTagsPage},
+		{"Generate archive pages", b.generateArchives},
+		{"Generate link graph", b.generateGraph},
+		{"Publish processed images", b.publishImages},
 	}
 
 	for _, step := range steps {
@@ -69,26 +123,77 @@ func (b *Builder) Build() error {
 		}
 	}
 
-	// 生成 RSS feed
-	feedPath := filepath.Join(b.publicDir, "feed.xml")
-	if err := rss.GenerateAndSaveFeed(
-		b.project.Posts,
-		b.project.Site.Title,
-		b.project.Site.BaseURL,
-		feedPath,
-	); err != nil {
-		return fmt.Errorf("failed to generate RSS feed: %w", err)
+	// 生成站点的 RSS、Atom 和 JSON Feed 订阅源
+	siteFeed := feed.New(b.project.Site, b.project.Posts, feed.Options{Limit: b.project.Site.Feeds.Limit})
+	if err := siteFeed.Save(b.publicDir, b.project.Site.Feeds); err != nil {
+		return fmt.Errorf("failed to generate feeds: %w", err)
+	}
+	if b.project.Site.Podcast.Enabled {
+		podcast := feed.NewPodcast(b.project.Site, b.project.Posts)
+		if err := podcast.WriteFile(b.publicDir, feed.PodcastFormat); err != nil {
+			return fmt.Errorf("failed to generate podcast feed: %w", err)
+		}
 	}
 
-	// 生成 sitemap
-	sitemapGen := sitemap.New(b.project.Site, b.project.Posts)
-	if err := sitemapGen.Generate(b.publicDir); err != nil {
+	// 生成 sitemap 和 robots.txt，包含以上步骤渲染的所有页面
+	if err := b.sitemap.Save(b.publicDir); err != nil {
 		return fmt.Errorf("failed to generate sitemap: %w", err)
 	}
+	if err := b.sitemap.SaveRobots(b.publicDir); err != nil {
+		return fmt.Errorf("failed to generate robots.txt: %w", err)
+	}
+
+	// 所有页面生成之后再替换其中引用的静态文件
+	if err := b.fingerprintStaticFiles(); err != nil {
+		return fmt.Errorf("failed to fingerprint static files: %w", err)
+	}
 
 	return nil
 }
 
+// fingerprintStaticFiles writes a content-hashed copy of every static file and
+// rewrites references in the generated HTML and XML. The original files are
+// kept for references that cannot be rewritten, such as paths built in scripts.
+func (b *Builder) fingerprintStaticFiles() error {
+	publicStaticDir := filepath.Join(b.publicDir, "static")
+
+	// 指纹在构建开始时已经计算，这里只写入带哈希的副本
+	for _, a := range b.assets.Assets() {
+		dest := filepath.Join(publicStaticDir, filepath.FromSlash(a.Path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(dest, a.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+
+	return filepath.Walk(b.publicDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == publicStaticDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".html" && ext != ".xml" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		rewritten := b.assets.RewriteURLs(content)
+		if bytes.Equal(rewritten, content) {
+			return nil
+		}
+		return os.WriteFile(path, rewritten, info.Mode())
+	})
+}
+
 // cleanPublicDir cleans and recreates the public directory
 func (b *Builder) cleanPublicDir() error {
 	if err := os.RemoveAll(b.publicDir); err != nil {
@@ -97,6 +202,22 @@ func (b *Builder) cleanPublicDir() error {
 	return os.MkdirAll(b.publicDir, 0755)
 }
 
+// generateHighlightCSS writes the chroma stylesheet used by class-based code highlighting
+func (b *Builder) generateHighlightCSS() error {
+	if b.highlight == nil {
+		return nil
+	}
+
+	cssPath := filepath.Join(b.publicDir, "static", "css", "chroma.css")
+	if err := os.MkdirAll(filepath.Dir(cssPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(cssPath, b.highlight, 0644); err != nil {
+		return fmt.Errorf("failed to write highlight css: %w", err)
+	}
+	return nil
+}
+
 // parsePosts reads and parses all markdown posts
 func (b *Builder) parsePosts() error {
 	postsDir := filepath.Join(b.project.Path, "content", "posts")
@@ -110,13 +231,13 @@ func (b *Builder) parsePosts() error {
 			return nil
 		}
 
-		// 只处理 .md 文件
-		if !strings.HasSuffix(info.Name(), ".md") {
+		// 只处理 .md 文件，_index.md 为分区信息
+		if !strings.HasSuffix(info.Name(), ".md") || info.Name() == post.IndexFile {
 			return nil
 		}
 
 		// 解析文章
-		parsePost, err := post.ParsePost(path)
+		parsePost, err := b.loader.Load(path)
 		if err != nil {
 			return fmt.Errorf("failed to parse post %s: %w", path, err)
 		}
@@ -153,6 +274,149 @@ func (b *Builder) parsePosts() error {
 		return b.project.Posts[i].Series < b.project.Posts[j].Series
 	})
 
+	for _, p := range b.project.Posts {
+		if err := b.store.Add(p); err != nil {
+			return fmt.Errorf("failed to add post to store: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseSections loads the sections and standalone pages outside content/posts
+func (b *Builder) parseSections() error {
+	sections, err := b.loader.LoadSections()
+	if err != nil {
+		return err
+	}
+	for _, section := range sections {
+		if b.isReservedPath(section.Name) {
+			log.Printf("Warning: section %s conflicts with a generated page and is skipped", section.Name)
+			continue
+		}
+		b.project.Sections = append(b.project.Sections, section)
+	}
+
+	pages, err := b.loader.LoadPages()
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		if b.isReservedPath(page.Slug) || b.findSection(page.Slug) != nil {
+			log.Printf("Warning: page %s conflicts with a generated page and is skipped", page.Slug)
+			continue
+		}
+		b.project.Pages = append(b.project.Pages, page)
+	}
+
+	return nil
+}
+
+// isReservedPath reports whether a top-level path is already used by generated pages
+func (b *Builder) isReservedPath(name string) bool {
+	if config.IsReservedPath(name) {
+		return true
+	}
+	for _, taxonomy := range b.project.Site.GetTaxonomies() {
+		if taxonomy.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findSection returns the section with the given name
+func (b *Builder) findSection(name string) *post.Section {
+	for _, section := range b.project.Sections {
+		if section.Name == name {
+			return section
+		}
+	}
+	return nil
+}
+
+// resolveLinks resolves wiki links between posts and computes backlinks
+func (b *Builder) resolveLinks() error {
+	return b.store.ResolveLinks()
+}
+
+// publishImages copies the resized images referenced by the generated pages
+func (b *Builder) publishImages() error {
+	images := b.loader.Images()
+	if images == nil {
+		return nil
+	}
+	return images.Publish(b.publicDir)
+}
+
+// generateGraph writes graph.json describing the wiki link network
+func (b *Builder) generateGraph() error {
+	data, err := json.MarshalIndent(b.store.Graph(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal link graph: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(b.publicDir, "graph.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write link graph: %w", err)
+	}
+	return nil
+}
+
+// syncGitHubProjects updates profile project stats from GitHub when github.sync is set.
+// Projects that cannot be fetched keep their configured values so offline builds still succeed
+func (b *Builder) syncGitHubProjects() error {
+	if !b.project.Site.GitHub.Sync {
+		return nil
+	}
+
+	client, err := github.NewClient(b.project.Site.GitHub, filepath.Join(b.project.Path, ".cache", "github"))
+	if err != nil {
+		return err
+	}
+	client.SyncProjects(&b.project.Site.Author)
+	return nil
+}
+
+// generateResume writes the author profile as JSON Resume to resume.json
+func (b *Builder) generateResume() error {
+	if b.project.Site.Author.Name == "" {
+		return nil
+	}
+
+	data, err := resume.FromConfig(b.project.Site).JSON()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(b.publicDir, "resume.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write resume: %w", err)
+	}
+	return nil
+}
+
+// generateCV renders the author profile to cv.pdf when cv.enabled is set
+func (b *Builder) generateCV() error {
+	if !b.project.Site.CV.Enabled {
+		return nil
+	}
+	return cv.Save(b.project.Path, b.project.Site, filepath.Join(b.publicDir, "cv.pdf"))
+}
+
+// generateShareImages renders an Open Graph image for every post when ogImage.enabled is set.
+// It runs before the pages are rendered because rendering rewrites the avatar path
+func (b *Builder) generateShareImages() error {
+	if !b.project.Site.OGImage.Enabled {
+		return nil
+	}
+
+	renderer, err := ogimage.New(b.project.Path, b.project.Site)
+	if err != nil {
+		return err
+	}
+	for _, p := range b.project.Posts {
+		file := filepath.Join(b.publicDir, "posts", p.Slug, ogimage.FileName)
+		if err := renderer.Save(ogimage.FromPost(p, b.project.Site), file); err != nil {
+			return fmt.Errorf("failed to generate share image for %s: %w", p.Slug, err)
+		}
+		p.OGImage = ogimage.Path(p)
+	}
 	return nil
 }
 
@@ -180,6 +444,7 @@ func (b *Builder) generatePost(post *post.Post) error {
 	// Prepare template data
 	templateData := map[string]interface{}{
 		"Title": post.Title + " - " + b.project.Site.Title,
+		"URL":   post.URL(),
 		"Post":  post,
 		"Posts": b.project.Posts,
 		"Site":  b.project.Site,
@@ -196,7 +461,104 @@ func (b *Builder) generatePost(post *post.Post) error {
 	if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 		return fmt.Errorf("failed to write post file: %w", err)
 	}
+	b.sitemap.AddPost(post)
+
+	return nil
+}
+
+// generateSections generates section list pages, section content pages and standalone pages
+func (b *Builder) generateSections() error {
+	for _, section := range b.project.Sections {
+		if err := b.generateSectionList(section); err != nil {
+			return fmt.Errorf("failed to generate section %s: %w", section.Name, err)
+		}
+		for _, page := range section.Pages {
+			if err := b.generatePage(section, page); err != nil {
+				return fmt.Errorf("failed to generate page %s: %w", page.URL(), err)
+			}
+		}
+	}
+
+	for _, page := range b.project.Pages {
+		if err := b.generatePage(nil, page); err != nil {
+			return fmt.Errorf("failed to generate page %s: %w", page.URL(), err)
+		}
+	}
+	return nil
+}
+
+// generateSectionList generates the paginated list pages of a section
+func (b *Builder) generateSectionList(section *post.Section) error {
+	pageSize := 10
+	total := len(section.Pages)
+	totalPages := (total + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	for page := 1; page <= totalPages; page++ {
+		start := (page - 1) * pageSize
+		end := start + pageSize
+		if end > total {
+			end = total
+		}
+
+		pagination := template.NewPagination(page, pageSize, total, section.URL())
+		data := map[string]interface{}{
+			"Title":      section.Title + " - " + b.project.Site.Title,
+			"URL":        pagination.URL(),
+			"Section":    section,
+			"Pages":      section.Pages[start:end],
+			"Pagination": pagination,
+			"TotalPosts": total,
+			"Site":       b.project.Site,
+		}
+
+		html, err := b.engine.RenderSection(section.Name, data)
+		if err != nil {
+			return fmt.Errorf("failed to render page %d: %w", page, err)
+		}
+
+		pageDir := filepath.Join(b.publicDir, section.Name)
+		if page > 1 {
+			pageDir = filepath.Join(pageDir, "page", fmt.Sprintf("%d", page))
+		}
+		if err := os.MkdirAll(pageDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(pageDir, "index.html"), []byte(html), 0644); err != nil {
+			return fmt.Errorf("failed to write page %d: %w", page, err)
+		}
+		b.sitemap.Add(sitemap.URL{Path: pagination.URL(), LastMod: sitemap.LastMod(section.Pages[start:end])})
+	}
+	return nil
+}
 
+// generatePage generates a section content page, or a standalone page when section is nil
+func (b *Builder) generatePage(section *post.Section, page *post.Post) error {
+	data := map[string]interface{}{
+		"Title": page.Title + " - " + b.project.Site.Title,
+		"URL":   page.URL(),
+		"Page":  page,
+		"Site":  b.project.Site,
+	}
+	if section != nil {
+		data["Section"] = section
+	}
+
+	html, err := b.engine.RenderPage(page.Section, data)
+	if err != nil {
+		return fmt.Errorf("failed to render page: %w", err)
+	}
+
+	pageDir := filepath.Join(b.publicDir, filepath.FromSlash(strings.TrimPrefix(page.URL(), "/")))
+	if err := os.MkdirAll(pageDir, 0755); err != nil {
+		return fmt.Errorf("failed to create page directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(pageDir, "index.html"), []byte(html), 0644); err != nil {
+		return fmt.Errorf("failed to write page file: %w", err)
+	}
+	b.sitemap.AddPost(page)
 	return nil
 }
 
@@ -204,6 +566,7 @@ func (b *Builder) generatePost(post *post.Post) error {
 func (b *Builder) generateIndex() error {
 	data := map[string]interface{}{
 		"Title": b.project.Site.Title,
+		"URL":   "/",
 		"Posts": b.project.Posts,
 		"Site":  b.project.Site,
 	}
@@ -218,6 +581,7 @@ func (b *Builder) generateIndex() error {
 	if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 		return fmt.Errorf("failed to write index.html: %w", err)
 	}
+	b.sitemap.Add(sitemap.URL{Path: "/", LastMod: sitemap.LastMod(b.project.Posts)})
 
 	return nil
 }
@@ -307,14 +671,6 @@ func (b *Builder) copyStaticFiles() error {
 
 // generateList generates the list page for posts
 func (b *Builder) generateList() error {
-	// 创建 post.Store 实例来获取统计信息
-	store := post.New()
-	for _, p := range b.project.Posts {
-		if err := store.Add(p); err != nil {
-			return fmt.Errorf("failed to add post to store: %w", err)
-		}
-	}
-
 	// 对系列按照 Order 排序
 	sort.Slice(b.project.Site.Series, func(i, j int) bool {
 		return b.project.Site.Series[i].Order < b.project.Site.Series[j].Order
@@ -322,12 +678,13 @@ func (b *Builder) generateList() error {
 
 	data := map[string]interface{}{
 		"Title":       "Posts - " + b.project.Site.Title,
+		"URL":         "/posts",
 		"Posts":       b.project.Posts,
 		"Site":        b.project.Site,
 		"BuildMode":   true,
-		"SeriesStats": store.GetSeriesStats(),
-		"TagsStats":   store.GetTagsStats(),
-		"AllTags":     store.GetAllTags(),
+		"SeriesStats": b.store.GetSeriesStats(),
+		"TagsStats":   b.store.GetTagsStats(),
+		"AllTags":     b.store.GetAllTags(),
 	}
 
 	html, err := b.engine.RenderList(data)
@@ -346,6 +703,7 @@ func (b *Builder) generateList() error {
 	if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 		return fmt.Errorf("failed to write posts/index.html: %w", err)
 	}
+	b.sitemap.Add(sitemap.URL{Path: "/posts", LastMod: sitemap.LastMod(b.project.Posts)})
 
 	return nil
 }
@@ -363,14 +721,6 @@ func (b *Builder) generatePaginatedLists() error {
 	totalPosts := len(posts)
 	baseURL := "/posts"
 
-	// 创建 post.Store 实例来获取统计信息
-	store := post.New()
-	for _, p := range posts {
-		if err := store.Add(p); err != nil {
-			return fmt.Errorf("failed to add post to store: %w", err)
-		}
-	}
-
 	// 计算总页数
 	totalPages := (totalPosts + pageSize - 1) / pageSize
 
@@ -386,12 +736,13 @@ func (b *Builder) generatePaginatedLists() error {
 
 		data := map[string]interface{}{
 			"Title":       fmt.Sprintf("文章列表 - 第%d页 - %s", page, b.project.Site.Title),
+			"URL":         pagination.URL(),
 			"Posts":       posts[start:end],
 			"Site":        b.project.Site,
 			"BuildMode":   true,
-			"SeriesStats": store.GetSeriesStats(),
-			"TagsStats":   store.GetTagsStats(),
-			"AllTags":     store.GetAllTags(),
+			"SeriesStats": b.store.GetSeriesStats(),
+			"TagsStats":   b.store.GetTagsStats(),
+			"AllTags":     b.store.GetAllTags(),
 			"Pagination":  pagination,
 		}
 
@@ -417,6 +768,7 @@ func (b *Builder) generatePaginatedLists() error {
 		if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 			return fmt.Errorf("failed to write page %d: %w", page, err)
 		}
+		b.sitemap.Add(sitemap.URL{Path: pagination.URL(), LastMod: sitemap.LastMod(posts[start:end])})
 	}
 
 	return nil
@@ -424,58 +776,44 @@ func (b *Builder) generatePaginatedLists() error {
 
 // generateTaxonomyPages 生成分类页面
 func (b *Builder) generateTaxonomyPages() error {
-	// 创建 post.Store 实例来获取标签和系列统计
-	store := post.New()
-	for _, p := range b.project.Posts {
-		if err := store.Add(p); err != nil {
-			return fmt.Errorf("failed to add post to store: %w", err)
-		}
-	}
-
-	// 处理标签页面
-	tagPosts := make(map[string][]*post.Post)
-	for _, p := range b.project.Posts {
-		for _, tag := range p.Tags {
-			tagPosts[tag] = append(tagPosts[tag], p)
-		}
-	}
-
-	// 处理系列页面
-	seriesPosts := make(map[string][]*post.Post)
-	for _, p := range b.project.Posts {
-		if p.Series != "" {
-			seriesPosts[p.Series] = append(seriesPosts[p.Series], p)
+	for _, taxonomy := range b.project.Site.GetTaxonomies() {
+		termPosts := make(map[string][]*post.Post)
+		for _, p := range b.project.Posts {
+			for _, term := range p.Terms(taxonomy.Name) {
+				termPosts[term] = append(termPosts[term], p)
+			}
 		}
-	}
 
-	// 为配置文件中定义的所有系列创建页面，即使没有文章
-	for _, series := range b.project.Site.Series {
-		if _, exists := seriesPosts[series.Name]; !exists {
-			// 如果这个系列还没有文章，也要生成页面
-			if err := b.generatePaginatedTaxonomyPages("series", series.Name, []*post.Post{}); err != nil {
-				return fmt.Errorf("failed to generate empty series page for %s: %w", series.Name, err)
+		// 为配置文件中定义的所有系列创建页面，即使没有文章
+		if taxonomy.Name == "series" {
+			for _, series := range b.project.Site.Series {
+				if _, exists := termPosts[series.Name]; !exists {
+					if err := b.generatePaginatedTaxonomyPages(taxonomy, series.Name, []*post.Post{}); err != nil {
+						return fmt.Errorf("failed to generate empty series page for %s: %w", series.Name, err)
+					}
+				}
 			}
 		}
-	}
 
-	// 生成标签页面
-	for tag, posts := range tagPosts {
-		if err := b.generatePaginatedTaxonomyPages("tags", tag, posts); err != nil {
-			return fmt.Errorf("failed to generate tag pages: %w", err)
+		for term, posts := range termPosts {
+			if err := b.generatePaginatedTaxonomyPages(taxonomy, term, posts); err != nil {
+				return fmt.Errorf("failed to generate %s pages: %w", taxonomy.Name, err)
+			}
 		}
-	}
 
-	// 生成系列页面
-	for series, posts := range seriesPosts {
-		if err := b.generatePaginatedTaxonomyPages("series", series, posts); err != nil {
-			return fmt.Errorf("failed to generate series pages: %w", err)
+		// 标签使用单独的标签云页面
+		if taxonomy.Name != "tags" {
+			if err := b.generateTermsPage(taxonomy); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func (b *Builder) generatePaginatedTaxonomyPages(taxonomy, term string, posts []*post.Post) error {
+// generatePaginatedTaxonomyPages generates the paginated post lists and feeds of a taxonomy term
+func (b *Builder) generatePaginatedTaxonomyPages(taxonomy config.Taxonomy, term string, posts []*post.Post) error {
 	pageSize := 6
 	totalPosts := len(posts)
 	totalPages := (totalPosts + pageSize - 1) / pageSize
@@ -491,14 +829,6 @@ func (b *Builder) generatePaginatedTaxonomyPages(taxonomy, term string, posts []
 		return posts[i].Date.After(posts[j].Date)
 	})
 
-	// 创建 post.Store 实例来获取统计信息
-	store := post.New()
-	for _, p := range b.project.Posts {
-		if err := store.Add(p); err != nil {
-			return fmt.Errorf("failed to add post to store: %w", err)
-		}
-	}
-
 	// 生成分页
 	for page := 1; page <= totalPages; page++ {
 		// 计算当前页的文章
@@ -509,21 +839,27 @@ func (b *Builder) generatePaginatedTaxonomyPages(taxonomy, term string, posts []
 		}
 
 		// 创建分页数据
-		baseURL := fmt.Sprintf("/%s/%s", taxonomy, term)
+		baseURL := fmt.Sprintf("/%s/%s", taxonomy.Name, term)
 		pagination := template.NewPagination(page, pageSize, totalPosts, baseURL)
 
 		data := map[string]interface{}{
-			"Title":       fmt.Sprintf("%s: %s - 第%d页", taxonomy, term, page),
-			"Posts":       posts[start:end],
-			"Site":        b.project.Site,
-			"Taxonomy":    taxonomy,
-			"Term":        term,
-			"SeriesStats": store.GetSeriesStats(),
-			"TagsStats":   store.GetTagsStats(),
-			"AllTags":     store.GetAllTags(),
-			"Pagination":  pagination,
-			"TotalPosts":  totalPosts,
-			"BuildMode":   true,
+			"Title":         fmt.Sprintf("%s: %s - 第%d页", taxonomy.Title, term, page),
+			"URL":           pagination.URL(),
+			"Posts":         posts[start:end],
+			"Site":          b.project.Site,
+			"Taxonomy":      taxonomy.Name,
+			"TaxonomyTitle": taxonomy.Title,
+			"Term":          term,
+			"TermsStats":    b.store.GetTermsStats(taxonomy.Name),
+			"SeriesStats":   b.store.GetSeriesStats(),
+			"TagsStats":     b.store.GetTagsStats(),
+			"AllTags":       b.store.GetAllTags(),
+			"Pagination":    pagination,
+			"TotalPosts":    totalPosts,
+			"BuildMode":     true,
+		}
+		if author, ok := b.project.Site.GetAuthor(term); ok && taxonomy.Name == "authors" {
+			data["Author"] = author
 		}
 
 		// 生成页面
@@ -538,9 +874,9 @@ func (b *Builder) generatePaginatedTaxonomyPages(taxonomy, term string, posts []
 		// 创建目录并写入文件
 		var pageDir string
 		if page == 1 {
-			pageDir = filepath.Join(b.publicDir, taxonomy, dirName)
+			pageDir = filepath.Join(b.publicDir, taxonomy.Name, dirName)
 		} else {
-			pageDir = filepath.Join(b.publicDir, taxonomy, dirName, "page", fmt.Sprintf("%d", page))
+			pageDir = filepath.Join(b.publicDir, taxonomy.Name, dirName, "page", fmt.Sprintf("%d", page))
 		}
 
 		if err := os.MkdirAll(pageDir, 0755); err != nil {
@@ -551,28 +887,80 @@ func (b *Builder) generatePaginatedTaxonomyPages(taxonomy, term string, posts []
 		if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 			return fmt.Errorf("failed to write page %d: %w", page, err)
 		}
+		b.sitemap.Add(sitemap.URL{
+			Path:    filepath.ToSlash(strings.TrimPrefix(pageDir, b.publicDir)),
+			LastMod: sitemap.LastMod(posts[start:end]),
+		})
+	}
+
+	// 生成分类项的订阅源，作者的订阅源使用作者名称作为标题
+	if totalPosts > 0 {
+		title := term
+		if author, ok := b.project.Site.GetAuthor(term); ok && taxonomy.Name == "authors" && author.Name != "" {
+			title = author.Name
+		}
+
+		dirName := strings.ReplaceAll(term, " ", "-")
+		termFeed := feed.New(b.project.Site, posts, feed.Options{
+			Title: fmt.Sprintf("%s - %s", title, b.project.Site.Title),
+			Path:  "/" + taxonomy.Name + "/" + dirName,
+			Limit: feed.TermLimit(b.project.Site.Feeds),
+		})
+		if err := termFeed.Save(filepath.Join(b.publicDir, taxonomy.Name, dirName), b.project.Site.Feeds); err != nil {
+			return fmt.Errorf("failed to generate feed for %s %s: %w", taxonomy.Name, term, err)
+		}
 	}
 
 	return nil
 }
 
-// generateTagsPage 生成标签云页面
-func (b *Builder) generateTagsPage() error {
-	// 创建 post.Store 实例来获取标签统计
-	store := post.New()
-	for _, p := range b.project.Posts {
-		if err := store.Add(p); err != nil {
-			return fmt.Errorf("failed to add post to store: %w", err)
-		}
+// generateTermsPage generates the page listing every term of a taxonomy.
+// Themes without a terms template skip it
+func (b *Builder) generateTermsPage(taxonomy config.Taxonomy) error {
+	if !b.engine.HasTemplate("terms", "") {
+		log.Printf("Warning: theme has no _default/terms.html, the /%s/ page is skipped", taxonomy.Name)
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"Title":         taxonomy.Title + " - " + b.project.Site.Title,
+		"URL":           "/" + taxonomy.Name,
+		"Taxonomy":      taxonomy.Name,
+		"TaxonomyTitle": taxonomy.Title,
+		"Terms":         b.store.GetAllTerms(taxonomy.Name),
+		"TermsStats":    b.store.GetTermsStats(taxonomy.Name),
+		"Site":          b.project.Site,
+		"BuildMode":     true,
+	}
+
+	html, err := b.engine.RenderTerms(data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s page: %w", taxonomy.Name, err)
+	}
+
+	dir := filepath.Join(b.publicDir, taxonomy.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", taxonomy.Name, err)
 	}
 
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644); err != nil {
+		return fmt.Errorf("failed to write %s index: %w", taxonomy.Name, err)
+	}
+	b.sitemap.Add(sitemap.URL{Path: "/" + taxonomy.Name, LastMod: b.taxonomyLastMod(taxonomy.Name)})
+
+	return nil
+}
+
+// generateTagsPage 生成标签云页面
+func (b *Builder) generateTagsPage() error {
 	// 获取所有标签和统计信息
-	allTags := store.GetAllTags()
-	tagsStats := store.GetTagsStats()
+	allTags := b.store.GetAllTags()
+	tagsStats := b.store.GetTagsStats()
 
 	// 生成标签云页面
 	data := map[string]interface{}{
 		"Title":     "标签云 - " + b.project.Site.Title,
+		"URL":       "/tags",
 		"AllTags":   allTags,
 		"TagsStats": tagsStats,
 		"Site":      b.project.Site,
@@ -595,6 +983,105 @@ func (b *Builder) generateTagsPage() error {
 	if err := os.WriteFile(indexPath, []byte(html), 0644); err != nil {
 		return fmt.Errorf("failed to write tags index: %w", err)
 	}
+	b.sitemap.Add(sitemap.URL{Path: "/tags", LastMod: b.taxonomyLastMod("tags")})
+
+	return nil
+}
+
+// taxonomyLastMod returns the latest modification time of the posts that have terms in a taxonomy
+func (b *Builder) taxonomyLastMod(taxonomy string) time.Time {
+	var posts []*post.Post
+	for _, p := range b.project.Posts {
+		if len(p.Terms(taxonomy)) > 0 {
+			posts = append(posts, p)
+		}
+	}
+	return sitemap.LastMod(posts)
+}
+
+// generateArchives generates the archive pages by year and month.
+// Themes without an archive template skip them
+func (b *Builder) generateArchives() error {
+	if !b.engine.HasTemplate("archive", "archives") {
+		log.Printf("Warning: theme has no _default/archive.html, archive pages are skipped")
+		return nil
+	}
+
+	archives := b.store.GetArchives()
+	yearStats := b.store.GetYearStats()
+	monthStats := b.store.GetMonthStats()
+
+	bySlug := make(map[string]*post.Post, len(b.project.Posts))
+	for _, p := range b.project.Posts {
+		bySlug[p.Slug] = p
+	}
+
+	// render 渲染一个归档页面并写入 archives 下的目录
+	render := func(title string, year, month int, archives []*post.ArchiveYear, dir ...string) error {
+		total := 0
+		for _, y := range archives {
+			total += y.Count
+		}
+
+		url := "/" + strings.Join(append([]string{"archives"}, dir...), "/")
+		data := map[string]interface{}{
+			"Title":      title + " - " + b.project.Site.Title,
+			"URL":        url,
+			"Archives":   archives,
+			"Year":       year,
+			"Month":      month,
+			"YearStats":  yearStats,
+			"MonthStats": monthStats,
+			"TotalPosts": total,
+			"Site":       b.project.Site,
+			"BuildMode":  true,
+		}
+
+		html, err := b.engine.RenderArchive(data)
+		if err != nil {
+			return fmt.Errorf("failed to render archive page: %w", err)
+		}
+
+		pageDir := filepath.Join(append([]string{b.publicDir, "archives"}, dir...)...)
+		if err := os.MkdirAll(pageDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(pageDir, "index.html"), []byte(html), 0644); err != nil {
+			return err
+		}
+
+		var posts []*post.Post
+		for _, y := range archives {
+			for _, m := range y.Months {
+				for _, meta := range m.Posts {
+					if p, ok := bySlug[meta.Slug]; ok {
+						posts = append(posts, p)
+					}
+				}
+			}
+		}
+		b.sitemap.Add(sitemap.URL{Path: url, LastMod: sitemap.LastMod(posts)})
+		return nil
+	}
+
+	if err := render("归档", 0, 0, archives); err != nil {
+		return err
+	}
+
+	for _, y := range archives {
+		yearDir := fmt.Sprintf("%d", y.Year)
+		if err := render(fmt.Sprintf("%d 年归档", y.Year), y.Year, 0, []*post.ArchiveYear{y}, yearDir); err != nil {
+			return err
+		}
+
+		for _, m := range y.Months {
+			scope := []*post.ArchiveYear{{Year: y.Year, Count: m.Count, Months: []*post.ArchiveMonth{m}}}
+			title := fmt.Sprintf("%d 年 %d 月归档", y.Year, int(m.Month))
+			if err := render(title, y.Year, int(m.Month), scope, yearDir, fmt.Sprintf("%02d", int(m.Month))); err != nil {
+				return err
+			}
+		}
+	}
 
 	return nil
 }
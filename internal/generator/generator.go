@@ -17,7 +17,7 @@ import (
 	"github.com/jiangjiax/stars/internal/post"
 	"github.com/jiangjiax/stars/internal/template"
 	"github.com/jiangjiax/stars/internal/template/funcs"
-	"github.com/jiangjiax/stars/internal/sitemap"
+	"github.com/jiangjiax/stars/internal/theme"
 )
 
 //go:embed templates/config.yaml templates/example-posts/* templates/default-theme/* templates/default-theme/**/*
@@ -31,6 +31,8 @@ type Project struct {
 	Date        string
 	Site        *config.Config
 	Posts       []*post.Post
+	Sections    []*post.Section   // content 下除 posts 以外的分区
+	Pages       []*post.Post      // content 下的独立页面
 	Post        *post.Post        // 当前文章（用于单文章页面）
 	template    *stdtmpl.Template // 使用标准库的模板类型
 }
@@ -78,6 +80,7 @@ func (p *Project) Generate() error {
 	// 1. 创建基本目录结构
 	dirs := []string{
 		"content/posts",
+		"data",
 		"public",
 		"themes",
 	}
@@ -140,12 +143,6 @@ func (p *Project) Generate() error {
 		return fmt.Errorf("failed to generate vercel.json: %w", err)
 	}
 
-	// 生成 sitemap
-	sitemapGen := sitemap.New(p.Site, p.Posts)
-	if err := sitemapGen.Generate(p.Path); err != nil {
-		return fmt.Errorf("failed to generate sitemap: %w", err)
-	}
-
 	// 标记生成成功
 	success = true
 	return nil
@@ -437,9 +434,18 @@ func (p *Project) generateFromTemplate(destPath, templatePath string, data inter
 	return nil
 }
 
-// installDependencies 安装项目依赖
+// installDependencies 安装主题的 npm 依赖，与 asset.BuildAssets 一致，
+// 只有主题在 theme.yaml 中设置 assets.npm 时才需要 npm
 func (p *Project) installDependencies() error {
 	themePath := filepath.Join(p.Path, "themes", "default")
+	t, err := theme.Load(themePath)
+	if err != nil {
+		return err
+	}
+	if !t.Assets.NPM {
+		return nil
+	}
+
 	pkgPath := filepath.Join(themePath, "package.json")
 
 	if _, err := os.Stat(pkgPath); os.IsNotExist(err) {
@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerate 运行 stars new 的流程：从内置模板创建项目并生成目录和文件
+func TestGenerate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blog")
+
+	project, err := New(path, true)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := project.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, name := range []string{
+		"config.yaml",
+		"vercel.json",
+		"content/posts",
+		"themes/default/theme.yaml",
+		"themes/default/layouts/_default/baseof.html",
+	} {
+		if _, err := os.Stat(filepath.Join(path, filepath.FromSlash(name))); err != nil {
+			t.Errorf("expected %s to be generated: %v", name, err)
+		}
+	}
+}
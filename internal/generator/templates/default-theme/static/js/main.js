@@ -102,11 +102,37 @@ function initProfile() {
     generateQRCode();
 }
 
+// 代码块复制按钮，按钮由 markdown 渲染时输出 data-copy 属性
+function initCodeCopy() {
+    document.addEventListener('click', async (event) => {
+        const button = event.target.closest('[data-copy]');
+        if (!button) return;
+
+        const pre = button.closest('.code-block')?.querySelector('pre');
+        if (!pre) return;
+
+        // 带行号时只复制代码部分
+        const lines = pre.querySelectorAll('.cl');
+        const text = lines.length > 0
+            ? Array.from(lines, line => line.textContent).join('')
+            : pre.textContent;
+
+        try {
+            await navigator.clipboard.writeText(text);
+            window.showToast('代码已复制', 2000);
+        } catch (error) {
+            console.error('Failed to copy code:', error);
+            window.showToast('复制失败', 2000, 'error');
+        }
+    });
+}
+
 // 初始化所有功能
 document.addEventListener('DOMContentLoaded', () => {
     console.log('DOM loaded');
     initProfile();     // 初始化个人资料功能
     initStars();       // 初始化星空背景
+    initCodeCopy();    // 初始化代码复制按钮
 });
 
 // 处理窗口大小改变
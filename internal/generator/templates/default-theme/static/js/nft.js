@@ -1,4 +1,5 @@
-import { ethers } from 'ethers';
+// ethers 从 CDN 以 ES 模块加载，构建时不需要安装 npm 依赖
+import { ethers } from 'https://esm.sh/ethers@5.7.2';
 
 // 从页面元素获取文章数据
 function getArticleData() {
@@ -0,0 +1,211 @@
+// Package github 通过 GitHub REST API 同步个人资料中项目的星标、fork 等数据
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+// DefaultAPIURL GitHub REST API 地址
+const DefaultAPIURL = "https://api.github.com"
+
+// DefaultCacheTTL 缓存默认有效期
+const DefaultCacheTTL = 24 * time.Hour
+
+// Repo 仓库数据
+type Repo struct {
+	FullName string    `json:"full_name"`
+	Stars    int       `json:"stargazers_count"`
+	Forks    int       `json:"forks_count"`
+	Language string    `json:"language"`
+	Topics   []string  `json:"topics"`
+	PushedAt time.Time `json:"pushed_at"`
+}
+
+// cacheEntry 磁盘缓存中的仓库数据
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Repo      *Repo     `json:"repo"`
+}
+
+// Client GitHub API 客户端，响应缓存在磁盘上
+type Client struct {
+	apiURL   string
+	token    string
+	cacheDir string
+	ttl      time.Duration
+	http     *http.Client
+}
+
+// NewClient 根据配置创建客户端，cacheDir 为空时不使用缓存
+func NewClient(cfg config.GitHub, cacheDir string) (*Client, error) {
+	ttl := DefaultCacheTTL
+	if cfg.CacheTTL != "" {
+		d, err := time.ParseDuration(cfg.CacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid github cacheTTL %q: %w", cfg.CacheTTL, err)
+		}
+		ttl = d
+	}
+
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	token := cfg.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	return &Client{
+		apiURL:   strings.TrimSuffix(apiURL, "/"),
+		token:    token,
+		cacheDir: cacheDir,
+		ttl:      ttl,
+		http:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Repo 获取仓库数据。缓存未过期时直接使用缓存；
+// 请求失败时使用过期的缓存，以便离线时也能构建
+func (c *Client) Repo(owner, name string) (*Repo, error) {
+	cached, err := c.readCache(owner, name)
+	if err == nil && time.Since(cached.FetchedAt) < c.ttl {
+		return cached.Repo, nil
+	}
+
+	repo, err := c.fetch(owner, name)
+	if err != nil {
+		if cached != nil {
+			log.Printf("Warning: using cached data for %s/%s: %v", owner, name, err)
+			return cached.Repo, nil
+		}
+		return nil, err
+	}
+
+	if err := c.writeCache(owner, name, repo); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return repo, nil
+}
+
+// fetch 请求 GET /repos/{owner}/{repo}
+func (c *Client) fetch(owner, name string) (*Repo, error) {
+	endpoint := c.apiURL + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s/%s: %w", owner, name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s/%s: %s", owner, name, resp.Status)
+	}
+
+	var repo Repo
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return nil, fmt.Errorf("failed to decode %s/%s: %w", owner, name, err)
+	}
+	return &repo, nil
+}
+
+// cachePath 仓库数据的缓存文件
+func (c *Client) cachePath(owner, name string) string {
+	return filepath.Join(c.cacheDir, strings.ToLower(owner+"_"+name)+".json")
+}
+
+func (c *Client) readCache(owner, name string) (*cacheEntry, error) {
+	if c.cacheDir == "" {
+		return nil, os.ErrNotExist
+	}
+
+	data, err := os.ReadFile(c.cachePath(owner, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Repo == nil {
+		return nil, fmt.Errorf("invalid cache for %s/%s", owner, name)
+	}
+	return &entry, nil
+}
+
+func (c *Client) writeCache(owner, name string, repo *Repo) error {
+	if c.cacheDir == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cacheEntry{FetchedAt: time.Now(), Repo: repo}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal github cache: %w", err)
+	}
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create github cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.cachePath(owner, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write github cache: %w", err)
+	}
+	return nil
+}
+
+// ParseRepoURL 从 https://github.com/owner/repo 形式的地址中解析仓库，不是 GitHub 仓库时返回 false
+func ParseRepoURL(rawURL string) (owner, name string, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.EqualFold(strings.TrimPrefix(u.Host, "www."), "github.com") {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// SyncProjects 更新个人资料中 GitHub 项目的数据，返回更新的项目数。
+// 单个项目获取失败时只输出警告，不影响其他项目
+func (c *Client) SyncProjects(profile *config.Profile) int {
+	updated := 0
+	for i := range profile.Projects {
+		project := &profile.Projects[i]
+		owner, name, ok := ParseRepoURL(project.URL)
+		if !ok {
+			continue
+		}
+
+		repo, err := c.Repo(owner, name)
+		if err != nil {
+			log.Printf("Warning: failed to sync project %s: %v", project.Name, err)
+			continue
+		}
+
+		project.Stars = repo.Stars
+		project.Forks = repo.Forks
+		project.Language = repo.Language
+		project.Topics = repo.Topics
+		project.PushedAt = repo.PushedAt
+		updated++
+	}
+	return updated
+}
@@ -0,0 +1,185 @@
+// Package gitinfo 从 git 仓库读取文件的提交记录
+package gitinfo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit 修改过文件的一次提交
+type Commit struct {
+	Hash        string
+	AbbrevHash  string // 前 7 位
+	Subject     string // 提交说明的第一行
+	Message     string // 完整的提交说明
+	AuthorName  string
+	AuthorEmail string
+	AuthorDate  time.Time
+	CommitDate  time.Time
+	URL         string // 提交的网页地址，未配置仓库地址时为空
+}
+
+// Repo 一个 git 仓库中所有文件的提交记录
+//
+// 第一次查询时遍历 HEAD 的全部历史，HEAD 变化后（如预览时有了新的提交）重新遍历
+type Repo struct {
+	repo    *git.Repository
+	root    string
+	repoURL string
+
+	mu      sync.Mutex
+	head    plumbing.Hash
+	history map[string][]*Commit // 相对仓库根目录的路径 -> 提交记录，最新的在前
+}
+
+// Open 打开 dir 所在的 git 仓库，repoURL 为仓库的网页地址，用于生成提交链接
+func Open(dir, repoURL string) (*Repo, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git worktree: %w", err)
+	}
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+
+	return newRepo(repo, root, repoURL), nil
+}
+
+// newRepo 使用已打开的仓库创建 Repo，root 为工作区的根目录
+func newRepo(repo *git.Repository, root, repoURL string) *Repo {
+	return &Repo{
+		repo:    repo,
+		root:    root,
+		repoURL: strings.TrimRight(repoURL, "/"),
+	}
+}
+
+// History 文件的提交记录，最新的在前；文件未提交过时返回空
+func (r *Repo) History(path string) ([]*Commit, error) {
+	rel, err := r.rel(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	head, err := r.repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil // 仓库中还没有提交
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if r.history == nil || head.Hash() != r.head {
+		if err := r.index(head.Hash()); err != nil {
+			return nil, err
+		}
+	}
+	return r.history[rel], nil
+}
+
+// rel 文件相对仓库根目录的路径，使用 / 分隔
+func (r *Repo) rel(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(r.root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the git repository %s", path, r.root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// index 遍历 head 的历史，记录每个提交修改的文件
+//
+// 合并提交只与第一个父提交比较，浅克隆中最早的提交视为修改了其中的所有文件
+func (r *Repo) index(head plumbing.Hash) error {
+	iter, err := r.repo.Log(&git.LogOptions{From: head, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return fmt.Errorf("failed to read git log: %w", err)
+	}
+	defer iter.Close()
+
+	history := make(map[string][]*Commit)
+	err = iter.ForEach(func(c *object.Commit) error {
+		tree, err := c.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to read tree of %s: %w", c.Hash, err)
+		}
+
+		var parentTree *object.Tree
+		if c.NumParents() > 0 {
+			parent, err := c.Parent(0)
+			if err == nil {
+				parentTree, err = parent.Tree()
+			}
+			if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+				return fmt.Errorf("failed to read parent of %s: %w", c.Hash, err)
+			}
+		}
+
+		changes, err := object.DiffTree(parentTree, tree)
+		if err != nil {
+			return fmt.Errorf("failed to diff %s: %w", c.Hash, err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		commit := r.newCommit(c)
+		for _, change := range changes {
+			name := change.To.Name
+			if name == "" {
+				continue // 删除的文件
+			}
+			history[name] = append(history[name], commit)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.head = head
+	r.history = history
+	return nil
+}
+
+func (r *Repo) newCommit(c *object.Commit) *Commit {
+	hash := c.Hash.String()
+	message := strings.TrimSpace(c.Message)
+	subject, _, _ := strings.Cut(message, "\n")
+
+	commit := &Commit{
+		Hash:        hash,
+		AbbrevHash:  hash[:7],
+		Subject:     strings.TrimSpace(subject),
+		Message:     message,
+		AuthorName:  c.Author.Name,
+		AuthorEmail: c.Author.Email,
+		AuthorDate:  c.Author.When,
+		CommitDate:  c.Committer.When,
+	}
+	if r.repoURL != "" {
+		commit.URL = r.repoURL + "/commit/" + hash
+	}
+	return commit
+}
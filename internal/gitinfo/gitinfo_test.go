@@ -0,0 +1,139 @@
+package gitinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// testRepo 内存中的 git 仓库，工作区根目录为 /
+type testRepo struct {
+	t        *testing.T
+	fs       billy.Filesystem
+	worktree *git.Worktree
+	when     time.Time
+}
+
+func newTestRepo(t *testing.T) (*testRepo, *git.Repository) {
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testRepo{t: t, fs: fs, worktree: worktree, when: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, repo
+}
+
+// commit 写入或删除（内容为空）文件后提交，每次提交的时间晚一天
+func (r *testRepo) commit(message string, files map[string]string) {
+	r.t.Helper()
+	for name, content := range files {
+		if content == "" {
+			if _, err := r.worktree.Remove(name); err != nil {
+				r.t.Fatal(err)
+			}
+			continue
+		}
+		f, err := r.fs.Create(name)
+		if err != nil {
+			r.t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			r.t.Fatal(err)
+		}
+		f.Close()
+		if _, err := r.worktree.Add(name); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+
+	r.when = r.when.Add(24 * time.Hour)
+	sig := &object.Signature{Name: "Alice", Email: "alice@example.com", When: r.when}
+	if _, err := r.worktree.Commit(message, &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func subjects(commits []*Commit) []string {
+	var s []string
+	for _, c := range commits {
+		s = append(s, c.Subject)
+	}
+	return s
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHistory(t *testing.T) {
+	r, repo := newTestRepo(t)
+	gitRepo := newRepo(repo, "/", "https://github.com/alice/blog/")
+
+	// 还没有提交时没有记录
+	if history, err := gitRepo.History("/content/a.md"); err != nil || history != nil {
+		t.Fatalf("History() on an empty repository = %v, %v", history, err)
+	}
+
+	r.commit("Add posts", map[string]string{"content/a.md": "a", "content/b.md": "b"})
+	r.commit("Update a\n\nFix typos", map[string]string{"content/a.md": "a2"})
+	r.commit("Remove b", map[string]string{"content/b.md": ""})
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/content/a.md", []string{"Update a", "Add posts"}},
+		{"/content/b.md", []string{"Add posts"}},
+		{"/content/missing.md", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			history, err := gitRepo.History(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := subjects(history); !equal(got, tt.want) {
+				t.Errorf("History() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	history, _ := gitRepo.History("/content/a.md")
+	latest := history[0]
+	if latest.Message != "Update a\n\nFix typos" || latest.AuthorName != "Alice" || latest.AuthorEmail != "alice@example.com" {
+		t.Errorf("latest commit = %+v", latest)
+	}
+	if want := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC); !latest.AuthorDate.Equal(want) {
+		t.Errorf("AuthorDate = %v, want %v", latest.AuthorDate, want)
+	}
+	if latest.AbbrevHash != latest.Hash[:7] || latest.URL != "https://github.com/alice/blog/commit/"+latest.Hash {
+		t.Errorf("hash = %s, abbrev = %s, url = %s", latest.Hash, latest.AbbrevHash, latest.URL)
+	}
+
+	// HEAD 变化后重新读取历史
+	r.commit("Update a again", map[string]string{"content/a.md": "a3"})
+	history, err := gitRepo.History("/content/a.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := subjects(history), []string{"Update a again", "Update a", "Add posts"}; !equal(got, want) {
+		t.Errorf("History() after a new commit = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,435 @@
+// Package imaging 在构建时为本地图片生成多种宽度和 WebP 格式的版本，
+// 结果按原图内容哈希缓存在项目的 .cache/images 目录
+package imaging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"image"
+	_ "image/gif" // 注册 GIF 解码器，GIF 图片不处理但需要识别格式
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/jiangjiax/stars/internal/config"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+)
+
+// URLPrefix 处理后的图片在站点中的地址前缀
+const URLPrefix = "/_images/"
+
+// DefaultWidths 默认生成的宽度
+var DefaultWidths = []int{480, 960, 1600}
+
+// DefaultQuality 默认的 JPEG 质量
+const DefaultQuality = 80
+
+// encoders 支持输出的格式
+var encoders = map[string]struct {
+	mime   string
+	encode func(w io.Writer, img image.Image, quality int) error
+}{
+	"jpeg": {"image/jpeg", func(w io.Writer, img image.Image, quality int) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	}},
+	"png": {"image/png", func(w io.Writer, img image.Image, quality int) error {
+		return png.Encode(w, img)
+	}},
+	"webp": {"image/webp", func(w io.Writer, img image.Image, quality int) error {
+		return nativewebp.Encode(w, img, nil)
+	}},
+}
+
+// Image 处理后的图片
+type Image struct {
+	URL    string
+	Width  int
+	Height int
+}
+
+// Source 同一格式的一组图片，对应 <picture> 中的 <source>
+type Source struct {
+	Type   string // MIME 类型，如 image/webp
+	Images []Image
+}
+
+// Srcset 生成 srcset 属性的值
+func (s Source) Srcset() string {
+	parts := make([]string, 0, len(s.Images))
+	for _, img := range s.Images {
+		parts = append(parts, fmt.Sprintf("%s %dw", img.URL, img.Width))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Largest 最大的一张图片
+func (s Source) Largest() Image {
+	return s.Images[len(s.Images)-1]
+}
+
+// Set 一张原图的所有版本
+type Set struct {
+	Src      string   // 原图地址
+	Width    int      // 原图宽度
+	Height   int      // 原图高度
+	Fallback Source   // 原图格式的各个宽度
+	Sources  []Source // 其他格式，如 WebP
+}
+
+// Processor 图片处理器
+type Processor struct {
+	widths     []int
+	formats    []string
+	quality    int
+	staticDirs []string
+	dir        string
+
+	mu   sync.Mutex
+	sets map[string]*Set
+	used map[string]bool // 本次构建引用的文件
+}
+
+// New 创建图片处理器，图片从项目和主题的 static 目录中查找
+func New(projectDir string, site *config.Config) *Processor {
+	p := &Processor{
+		widths:  DefaultWidths,
+		formats: []string{"webp"},
+		quality: DefaultQuality,
+		staticDirs: []string{
+			filepath.Join(projectDir, "static"),
+			filepath.Join(projectDir, "themes", site.Theme, "static"),
+		},
+		dir:  filepath.Join(projectDir, ".cache", "images"),
+		sets: make(map[string]*Set),
+		used: make(map[string]bool),
+	}
+
+	cfg := site.Imaging
+	if len(cfg.Widths) > 0 {
+		p.widths = append([]int(nil), cfg.Widths...)
+		sort.Ints(p.widths)
+	}
+	if cfg.Formats != nil {
+		p.formats = nil
+		for _, format := range cfg.Formats {
+			format = normalizeFormat(format)
+			if _, ok := encoders[format]; !ok {
+				log.Printf("Warning: unsupported image format %q, skipping", format)
+				continue
+			}
+			p.formats = append(p.formats, format)
+		}
+	}
+	if cfg.Quality > 0 {
+		p.quality = cfg.Quality
+	}
+	return p
+}
+
+// Dir 处理后图片的缓存目录，本地预览时直接从这里提供
+func (p *Processor) Dir() string {
+	return p.dir
+}
+
+// Process 生成图片的各个版本。不是 static 下的 JPEG 或 PNG 图片时返回 nil
+func (p *Processor) Process(src string) (*Set, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if set, ok := p.sets[src]; ok {
+		return set, nil
+	}
+
+	set, err := p.process(src)
+	if err != nil {
+		return nil, err
+	}
+	p.sets[src] = set
+	return set, nil
+}
+
+// Resize 生成指定宽度和格式的图片，format 为空时使用原图格式，宽度不超过原图
+func (p *Processor) Resize(src string, width int, format string) (*Image, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	orig, err := p.open(src)
+	if err != nil {
+		return nil, err
+	}
+	if orig == nil {
+		return nil, fmt.Errorf("unsupported image: %s", src)
+	}
+
+	if format == "" {
+		format = orig.format
+	}
+	format = normalizeFormat(format)
+	if _, ok := encoders[format]; !ok {
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+	if width <= 0 || width > orig.width {
+		width = orig.width
+	}
+
+	img, err := p.derive(orig, width, format)
+	if err != nil {
+		return nil, err
+	}
+	p.use(*img)
+	return img, nil
+}
+
+// Publish 将本次构建引用的图片复制到 publicDir/_images
+func (p *Processor) Publish(publicDir string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.used) == 0 {
+		return nil
+	}
+
+	dest := filepath.Join(publicDir, filepath.FromSlash(strings.Trim(URLPrefix, "/")))
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("failed to create images directory: %w", err)
+	}
+	for name := range p.used {
+		data, err := os.ReadFile(filepath.Join(p.dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read processed image: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dest, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write processed image: %w", err)
+		}
+	}
+	return nil
+}
+
+// original 原图
+type original struct {
+	name    string // 不含扩展名的文件名
+	format  string
+	width   int
+	height  int
+	hash    string
+	content []byte
+	img     image.Image // 需要生成新图片时才解码
+}
+
+func (p *Processor) process(src string) (*Set, error) {
+	orig, err := p.open(src)
+	if err != nil || orig == nil {
+		return nil, err
+	}
+
+	var widths []int
+	for _, w := range p.widths {
+		if w < orig.width {
+			widths = append(widths, w)
+		}
+	}
+	if len(widths) == 0 || orig.width <= p.widths[len(p.widths)-1] {
+		widths = append(widths, orig.width)
+	}
+
+	set := &Set{
+		Src:    src,
+		Width:  orig.width,
+		Height: orig.height,
+	}
+	set.Fallback, err = p.source(orig, widths, orig.format)
+	if err != nil {
+		return nil, err
+	}
+	largest := set.Fallback.Largest()
+
+	for _, format := range p.formats {
+		if format == orig.format {
+			continue
+		}
+		// WebP 为无损压缩，照片转换后可能比原格式更大，这时不使用该格式
+		img, err := p.derive(orig, largest.Width, format)
+		if err != nil {
+			return nil, err
+		}
+		if p.size(*img) >= p.size(largest) {
+			continue
+		}
+
+		source, err := p.source(orig, widths, format)
+		if err != nil {
+			return nil, err
+		}
+		set.Sources = append(set.Sources, source)
+	}
+	return set, nil
+}
+
+// source 生成各个宽度的图片并标记为需要发布
+func (p *Processor) source(orig *original, widths []int, format string) (Source, error) {
+	source := Source{Type: encoders[format].mime}
+	for _, w := range widths {
+		img, err := p.derive(orig, w, format)
+		if err != nil {
+			return Source{}, err
+		}
+		p.use(*img)
+		source.Images = append(source.Images, *img)
+	}
+	return source, nil
+}
+
+// derive 生成一张图片，文件名包含原图内容、宽度、格式和质量的哈希，缓存中已存在时直接使用
+func (p *Processor) derive(orig *original, width int, format string) (*Image, error) {
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%d", orig.hash, width, format, p.quality)))
+	name := fmt.Sprintf("%s.%x.%d.%s", orig.name, key[:8], width, extension(format))
+	height := (orig.height*width + orig.width/2) / orig.width
+	if height < 1 {
+		height = 1
+	}
+
+	file := filepath.Join(p.dir, name)
+	if _, err := os.Stat(file); err != nil {
+		if err := p.encode(orig, width, height, format, file); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Image{URL: URLPrefix + url.PathEscape(name), Width: width, Height: height}, nil
+}
+
+func (p *Processor) encode(orig *original, width, height int, format, file string) error {
+	if orig.img == nil {
+		img, _, err := image.Decode(bytes.NewReader(orig.content))
+		if err != nil {
+			return fmt.Errorf("failed to decode image %s: %w", orig.name, err)
+		}
+		orig.img = img
+	}
+
+	img := orig.img
+	if width != orig.width {
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+		img = dst
+	}
+
+	var buf bytes.Buffer
+	if err := encoders[format].encode(&buf, img, p.quality); err != nil {
+		return fmt.Errorf("failed to encode image %s: %w", orig.name, err)
+	}
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+	if err := os.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write image cache: %w", err)
+	}
+	return nil
+}
+
+// open 读取 static 下的原图，只处理 JPEG 和 PNG，其他图片返回 nil
+func (p *Processor) open(src string) (*original, error) {
+	rel, ok := staticPath(src)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, dir := range p.staticDirs {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil || (format != "jpeg" && format != "png") {
+			return nil, nil
+		}
+
+		sum := sha256.Sum256(content)
+		base := path.Base(rel)
+		return &original{
+			name:    strings.TrimSuffix(base, path.Ext(base)),
+			format:  format,
+			width:   cfg.Width,
+			height:  cfg.Height,
+			hash:    fmt.Sprintf("%x", sum),
+			content: content,
+		}, nil
+	}
+	return nil, nil
+}
+
+// use 标记图片被页面引用，构建时需要发布
+func (p *Processor) use(img Image) {
+	p.used[fileName(img)] = true
+}
+
+// size 图片文件的大小
+func (p *Processor) size(img Image) int64 {
+	info, err := os.Stat(filepath.Join(p.dir, fileName(img)))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// staticPath 从 /static/images/a.jpg、../static/images/a.jpg 等地址中取出相对于 static 目录的路径
+func staticPath(src string) (string, bool) {
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "", false
+	}
+
+	p := u.Path
+	for {
+		switch {
+		case strings.HasPrefix(p, "../"):
+			p = p[3:]
+		case strings.HasPrefix(p, "./"):
+			p = p[2:]
+		case strings.HasPrefix(p, "/"):
+			p = p[1:]
+		default:
+			if !strings.HasPrefix(p, "static/") {
+				return "", false
+			}
+			rel := path.Clean(strings.TrimPrefix(p, "static/"))
+			if rel == "." || strings.HasPrefix(rel, "../") {
+				return "", false
+			}
+			return rel, true
+		}
+	}
+}
+
+// fileName 图片在缓存目录中的文件名
+func fileName(img Image) string {
+	name, _ := url.PathUnescape(strings.TrimPrefix(img.URL, URLPrefix))
+	return name
+}
+
+func normalizeFormat(format string) string {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	if format == "jpg" {
+		return "jpeg"
+	}
+	return format
+}
+
+func extension(format string) string {
+	if format == "jpeg" {
+		return "jpg"
+	}
+	return format
+}
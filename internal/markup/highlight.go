@@ -0,0 +1,124 @@
+package markup
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/jiangjiax/stars/internal/config"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// 未配置时使用的代码高亮样式
+const defaultHighlightStyle = "monokai"
+
+// Highlighter 根据 markup.highlight 配置创建代码高亮渲染器
+//
+// 代码块支持 {hl_lines=[2,4] linenos=true linenostart=10 title="main.go"} 形式的属性
+func Highlighter(cfg config.Highlight) renderer.NodeRenderer {
+	return highlighting.NewHTMLRenderer(
+		highlighting.WithStyle(highlightStyle(cfg.Style).Name),
+		highlighting.WithGuessLanguage(cfg.GuessLanguage),
+		highlighting.WithFormatOptions(formatOptions(cfg)...),
+		highlighting.WithWrapperRenderer(wrapCodeBlock),
+	)
+}
+
+// WriteHighlightCSS 输出使用 CSS 类时代码高亮所需的样式，配置了 darkStyle 时追加深色模式样式
+func WriteHighlightCSS(w io.Writer, cfg config.Highlight) error {
+	formatter := chromahtml.New(formatOptions(cfg)...)
+	if err := formatter.WriteCSS(w, highlightStyle(cfg.Style)); err != nil {
+		return fmt.Errorf("failed to write highlight css: %w", err)
+	}
+
+	if cfg.DarkStyle == "" {
+		return nil
+	}
+	if _, err := io.WriteString(w, "@media (prefers-color-scheme: dark) {\n"); err != nil {
+		return err
+	}
+	if err := formatter.WriteCSS(w, highlightStyle(cfg.DarkStyle)); err != nil {
+		return fmt.Errorf("failed to write dark highlight css: %w", err)
+	}
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+// highlightStyle 获取 chroma 样式，名称无效时使用默认样式
+func highlightStyle(name string) *chroma.Style {
+	if name == "" {
+		name = defaultHighlightStyle
+	}
+	if style, ok := styles.Registry[name]; ok {
+		return style
+	}
+	return styles.Get(defaultHighlightStyle)
+}
+
+// formatOptions 配置对应的 chroma 输出选项
+func formatOptions(cfg config.Highlight) []chromahtml.Option {
+	opts := []chromahtml.Option{
+		chromahtml.WithClasses(!cfg.NoClasses),
+		chromahtml.WithLineNumbers(cfg.LineNos),
+	}
+	if cfg.TabWidth > 0 {
+		opts = append(opts, chromahtml.TabWidth(cfg.TabWidth))
+	}
+	return opts
+}
+
+// wrapCodeBlock 为代码块添加标题和复制按钮，主题通过 [data-copy] 绑定复制功能
+func wrapCodeBlock(w util.BufWriter, c highlighting.CodeBlockContext, entering bool) {
+	lang, hasLang := c.Language()
+	// 未识别出语言时 chroma 使用 fallback 词法分析器，不作为语言输出
+	hasLang = hasLang && string(lang) != lexers.Fallback.Config().Name
+
+	if !entering {
+		if !c.Highlighted() {
+			_, _ = w.WriteString("</code></pre>")
+		}
+		_, _ = w.WriteString("</div>\n")
+		return
+	}
+
+	_, _ = w.WriteString(`<div class="code-block"`)
+	if hasLang {
+		_, _ = w.WriteString(` data-lang="`)
+		_, _ = w.Write(util.EscapeHTML(lang))
+		_ = w.WriteByte('"')
+	}
+	_ = w.WriteByte('>')
+
+	if c.Attributes() != nil {
+		if title, ok := c.Attributes().Get([]byte("title")); ok {
+			_, _ = w.WriteString(`<div class="code-title">`)
+			_, _ = w.WriteString(html.EscapeString(attributeString(title)))
+			_, _ = w.WriteString("</div>")
+		}
+	}
+	_, _ = w.WriteString(`<button type="button" class="code-copy" data-copy aria-label="复制代码"><i class="far fa-copy"></i></button>`)
+
+	if !c.Highlighted() {
+		_, _ = w.WriteString("<pre><code")
+		if hasLang {
+			_, _ = w.WriteString(` class="language-`)
+			_, _ = w.Write(util.EscapeHTML(lang))
+			_ = w.WriteByte('"')
+		}
+		_ = w.WriteByte('>')
+	}
+}
+
+// attributeString 将属性值转换为字符串
+func attributeString(v interface{}) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,406 @@
+package markup
+
+import (
+	"bytes"
+	"html/template"
+	"image"
+	_ "image/gif"  // 注册 GIF 解码器
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/admonition"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/imaging"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// LinkContext render-link 模板的数据
+type LinkContext struct {
+	Destination string
+	Title       string
+	Text        template.HTML
+	PlainText   string
+	IsExternal  bool
+	Attributes  map[string]interface{}
+	Page        interface{}
+	Site        *config.Config
+}
+
+// ImageContext render-image 模板的数据
+type ImageContext struct {
+	Destination string
+	Title       string
+	Text        string           // 替代文本
+	Width       int              // 本地图片的宽度，无法读取时为 0
+	Height      int              // 本地图片的高度，无法读取时为 0
+	IsBlock     bool             // 是否独占一个段落
+	Srcset      string           // 启用图片处理时，原图格式各个宽度的 srcset
+	Sources     []imaging.Source // 启用图片处理时，WebP 等其他格式，用于 <picture>
+	Attributes  map[string]interface{}
+	Page        interface{}
+	Site        *config.Config
+}
+
+// HeadingContext render-heading 模板的数据
+type HeadingContext struct {
+	Level      int
+	Anchor     string
+	Text       template.HTML
+	PlainText  string
+	Attributes map[string]interface{}
+	Page       interface{}
+	Site       *config.Config
+}
+
+// CodeBlockContext render-codeblock 模板的数据
+type CodeBlockContext struct {
+	Type       string // 代码语言
+	Inner      string // 代码内容
+	Attributes map[string]interface{}
+	Page       interface{}
+	Site       *config.Config
+}
+
+// AdmonitionContext render-admonition 模板的数据
+type AdmonitionContext struct {
+	Type        string // 提示类型，如 note、tip、warning
+	Title       string
+	Text        template.HTML // 提示内容
+	Collapsible bool          // 是否可折叠
+	Open        bool          // 可折叠时是否默认展开
+	Attributes  map[string]interface{}
+	Page        interface{}
+	Site        *config.Config
+}
+
+// renderParagraph 独占段落的图片不再包裹 <p>，以便输出 <figure>
+func (h *Hooks) renderParagraph(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if standaloneImage(node) == nil {
+		return h.funcs[ast.KindParagraph](w, source, node, entering)
+	}
+	if !entering {
+		_ = w.WriteByte('\n')
+	}
+	return ast.WalkContinue, nil
+}
+
+// standaloneImage 返回段落中唯一的图片
+func standaloneImage(node ast.Node) *ast.Image {
+	if node.Kind() != ast.KindParagraph || node.ChildCount() != 1 {
+		return nil
+	}
+	img, _ := node.FirstChild().(*ast.Image)
+	return img
+}
+
+func (h *Hooks) renderLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Link)
+	tmpl, err := h.lookup("render-link")
+	if err != nil || tmpl == nil {
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		return h.funcs[ast.KindLink](w, source, node, entering)
+	}
+
+	text, err := h.renderChildren(source, n)
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	dest := string(n.Destination)
+	err = h.execute(w, tmpl, &LinkContext{
+		Destination: dest,
+		Title:       string(n.Title),
+		Text:        text,
+		PlainText:   PlainText(n, source),
+		IsExternal:  h.isExternal(dest),
+		Attributes:  attributes(n),
+		Page:        page(n),
+		Site:        h.site,
+	})
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (h *Hooks) renderAutoLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.AutoLink)
+	tmpl, err := h.lookup("render-link")
+	if err != nil || tmpl == nil {
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		return h.funcs[ast.KindAutoLink](w, source, node, entering)
+	}
+
+	dest := string(n.URL(source))
+	if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(strings.ToLower(dest), "mailto:") {
+		dest = "mailto:" + dest
+	}
+	label := string(n.Label(source))
+	err = h.execute(w, tmpl, &LinkContext{
+		Destination: dest,
+		Text:        template.HTML(template.HTMLEscapeString(label)),
+		PlainText:   label,
+		IsExternal:  h.isExternal(dest),
+		Attributes:  attributes(n),
+		Page:        page(n),
+		Site:        h.site,
+	})
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (h *Hooks) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Image)
+	tmpl, err := h.lookup("render-image")
+	if err != nil || tmpl == nil {
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		return h.funcs[ast.KindImage](w, source, node, entering)
+	}
+
+	dest := string(n.Destination)
+	width, height := h.imageSize(dest)
+	ctx := &ImageContext{
+		Destination: dest,
+		Title:       string(n.Title),
+		Text:        PlainText(n, source),
+		Width:       width,
+		Height:      height,
+		IsBlock:     standaloneImage(n.Parent()) != nil,
+		Attributes:  attributes(n),
+		Page:        page(n),
+		Site:        h.site,
+	}
+	if h.images != nil {
+		set, err := h.images.Process(dest)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else if set != nil {
+			ctx.Srcset = set.Fallback.Srcset()
+			ctx.Sources = set.Sources
+		}
+	}
+
+	if err := h.execute(w, tmpl, ctx); err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (h *Hooks) renderHeading(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Heading)
+	tmpl, err := h.lookup("render-heading")
+	if err != nil || tmpl == nil {
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		return h.funcs[ast.KindHeading](w, source, node, entering)
+	}
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	text, err := h.renderChildren(source, n)
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	attrs := attributes(n)
+	anchor, _ := attrs["id"].(string)
+	delete(attrs, "id")
+
+	err = h.execute(w, tmpl, &HeadingContext{
+		Level:      n.Level,
+		Anchor:     anchor,
+		Text:       text,
+		PlainText:  PlainText(n, source),
+		Attributes: attrs,
+		Page:       page(n),
+		Site:       h.site,
+	})
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	_ = w.WriteByte('\n')
+	return ast.WalkSkipChildren, nil
+}
+
+// renderCodeBlock 优先使用 render-codeblock-<语言> 模板，其次 render-codeblock，
+// 都不存在时交给代码高亮渲染
+func (h *Hooks) renderCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.FencedCodeBlock)
+	lang := string(n.Language(source))
+
+	var names []string
+	if lang != "" && namePattern.MatchString(lang) {
+		names = append(names, "render-codeblock-"+lang)
+	}
+	names = append(names, "render-codeblock")
+
+	for _, name := range names {
+		tmpl, err := h.lookup(name)
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		if tmpl == nil {
+			continue
+		}
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		err = h.execute(w, tmpl, &CodeBlockContext{
+			Type:       lang,
+			Inner:      string(code(n, source)),
+			Attributes: attributes(n),
+			Page:       page(n),
+			Site:       h.site,
+		})
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		_ = w.WriteByte('\n')
+		return ast.WalkSkipChildren, nil
+	}
+
+	// 图表代码块渲染为 SVG，失败时仍然按代码输出
+	if h.diagrams != nil && h.diagrams.Supports(lang) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		out, err := h.diagrams.Render(lang, code(n, source))
+		if err == nil {
+			_, _ = w.WriteString(out)
+			_ = w.WriteByte('\n')
+			return ast.WalkSkipChildren, nil
+		}
+		log.Printf("Warning: %v, rendering as code block", err)
+	}
+
+	return h.funcs[ast.KindFencedCodeBlock](w, source, node, entering)
+}
+
+// renderAdmonition 优先使用 render-admonition-<类型> 模板，其次 render-admonition
+func (h *Hooks) renderAdmonition(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*admonition.Admonition)
+	var names []string
+	if namePattern.MatchString(n.AdmonitionType) {
+		names = append(names, "render-admonition-"+n.AdmonitionType)
+	}
+	names = append(names, "render-admonition")
+
+	for _, name := range names {
+		tmpl, err := h.lookup(name)
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		if tmpl == nil {
+			continue
+		}
+
+		text, err := h.renderChildren(source, n)
+		if err != nil {
+			return ast.WalkStop, err
+		}
+
+		err = h.execute(w, tmpl, &AdmonitionContext{
+			Type:        n.AdmonitionType,
+			Title:       n.Title,
+			Text:        text,
+			Collapsible: n.Collapsible,
+			Open:        n.Open,
+			Attributes:  attributes(n),
+			Page:        page(n),
+			Site:        h.site,
+		})
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		_ = w.WriteByte('\n')
+		return ast.WalkSkipChildren, nil
+	}
+	return ast.WalkContinue, nil
+}
+
+// code 获取代码块的内容
+func code(n *ast.FencedCodeBlock, source []byte) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		buf.Write(line.Value(source))
+	}
+	return buf.Bytes()
+}
+
+// isExternal 判断链接是否指向站外
+func (h *Hooks) isExternal(dest string) bool {
+	u, err := url.Parse(dest)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	if h.site != nil && h.site.BaseURL != "" {
+		if base, err := url.Parse(h.site.BaseURL); err == nil && strings.EqualFold(base.Host, u.Host) {
+			return false
+		}
+	}
+	return true
+}
+
+// imageSize 读取本地图片（/static/ 下的文件）的尺寸
+func (h *Hooks) imageSize(dest string) (int, int) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Host != "" {
+		return 0, 0
+	}
+	clean := path.Clean(u.Path)
+	if !strings.HasPrefix(clean, "/static/") {
+		return 0, 0
+	}
+
+	rel := filepath.FromSlash(strings.TrimPrefix(clean, "/static/"))
+	for _, dir := range h.staticDir {
+		f, err := os.Open(filepath.Join(dir, rel))
+		if err != nil {
+			continue
+		}
+		cfg, _, err := image.DecodeConfig(f)
+		f.Close()
+		if err == nil {
+			return cfg.Width, cfg.Height
+		}
+	}
+	return 0, 0
+}
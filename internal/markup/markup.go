@@ -0,0 +1,256 @@
+package markup
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/jiangjiax/stars/internal/admonition"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/diagram"
+	"github.com/jiangjiax/stars/internal/imaging"
+	"github.com/jiangjiax/stars/internal/shortcode"
+	"github.com/jiangjiax/stars/internal/template/funcs"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	ghtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+//go:embed templates/*.html
+var builtins embed.FS
+
+// 合法的代码语言名称，用于拼接 render-codeblock-<语言> 模板名
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// pageAttr 文档节点上保存当前文章的属性名
+var pageAttr = []byte("stars:page")
+
+// Hooks 渲染钩子，使用 render-link、render-image、render-heading、
+// render-codeblock、render-admonition 模板替换 goldmark 的默认输出
+//
+// 查找顺序：项目 layouts/_markup > 主题 layouts/_markup > 内置模板
+type Hooks struct {
+	ghtml.Config
+	site      *config.Config
+	dirs      []string
+	staticDir []string
+	md        goldmark.Markdown
+	diagrams  *diagram.Diagrams
+	images    *imaging.Processor
+	fallback  []renderer.NodeRenderer
+	funcs     map[ast.NodeKind]renderer.NodeRendererFunc
+	mu        sync.Mutex
+	cache     map[string]*template.Template
+}
+
+// New 创建渲染钩子，fallback 用于没有对应模板时的输出（如代码高亮）
+func New(projectDir string, site *config.Config, fallback ...renderer.NodeRenderer) *Hooks {
+	h := &Hooks{
+		Config:   ghtml.NewConfig(),
+		site:     site,
+		fallback: append([]renderer.NodeRenderer{ghtml.NewRenderer()}, fallback...),
+		funcs:    make(map[ast.NodeKind]renderer.NodeRendererFunc),
+		cache:    make(map[string]*template.Template),
+	}
+	if projectDir != "" {
+		h.dirs = append(h.dirs, filepath.Join(projectDir, "layouts", "_markup"))
+		h.staticDir = append(h.staticDir, filepath.Join(projectDir, "static"))
+		if site != nil && site.Theme != "" {
+			h.dirs = append(h.dirs, filepath.Join(projectDir, "themes", site.Theme, "layouts", "_markup"))
+			h.staticDir = append(h.staticDir, filepath.Join(projectDir, "themes", site.Theme, "static"))
+		}
+	}
+	return h
+}
+
+// SetDiagrams 设置图表渲染器，mermaid、d2、dot 等代码块将渲染为图表
+func (h *Hooks) SetDiagrams(d *diagram.Diagrams) {
+	h.diagrams = d
+}
+
+// SetImages 设置图片处理器，本地图片将输出多种宽度和格式
+func (h *Hooks) SetImages(p *imaging.Processor) {
+	h.images = p
+}
+
+// Extend 实现 goldmark.Extender
+func (h *Hooks) Extend(m goldmark.Markdown) {
+	h.md = m
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(pageTransformer{}, 999),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(h, 100),
+	))
+}
+
+// SetOption 实现 renderer.SetOptioner，同时转发给回退渲染器
+func (h *Hooks) SetOption(name renderer.OptionName, value interface{}) {
+	h.Config.SetOption(name, value)
+	for _, r := range h.fallback {
+		if s, ok := r.(renderer.SetOptioner); ok {
+			s.SetOption(name, value)
+		}
+	}
+}
+
+// RegisterFuncs 实现 renderer.NodeRenderer
+func (h *Hooks) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	// 后注册的回退渲染器优先，与 goldmark 的优先级规则一致
+	for _, r := range h.fallback {
+		r.RegisterFuncs(funcCollector(h.funcs))
+	}
+
+	reg.Register(ast.KindParagraph, h.renderParagraph)
+	reg.Register(ast.KindLink, h.renderLink)
+	reg.Register(ast.KindAutoLink, h.renderAutoLink)
+	reg.Register(ast.KindImage, h.renderImage)
+	reg.Register(ast.KindHeading, h.renderHeading)
+	reg.Register(ast.KindFencedCodeBlock, h.renderCodeBlock)
+	reg.Register(admonition.KindAdmonition, h.renderAdmonition)
+}
+
+// funcCollector 收集回退渲染器的渲染函数
+type funcCollector map[ast.NodeKind]renderer.NodeRendererFunc
+
+func (c funcCollector) Register(kind ast.NodeKind, f renderer.NodeRendererFunc) {
+	c[kind] = f
+}
+
+// lookup 按优先级查找并缓存钩子模板，未找到时返回 nil
+func (h *Hooks) lookup(name string) (*template.Template, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if tmpl, ok := h.cache[name]; ok {
+		return tmpl, nil
+	}
+
+	content, err := h.read(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl *template.Template
+	if content != nil {
+		tmpl, err = template.New(name).Funcs(funcs.DefaultFuncs).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse render hook %s: %w", name, err)
+		}
+	}
+
+	h.cache[name] = tmpl
+	return tmpl, nil
+}
+
+// read 按优先级读取钩子模板内容
+func (h *Hooks) read(name string) ([]byte, error) {
+	for _, dir := range h.dirs {
+		content, err := os.ReadFile(filepath.Join(dir, name+".html"))
+		if err == nil {
+			return content, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read render hook %s: %w", name, err)
+		}
+	}
+
+	content, err := builtins.ReadFile("templates/" + name + ".html")
+	if err != nil {
+		return nil, nil
+	}
+	return content, nil
+}
+
+// execute 执行钩子模板并写入输出
+func (h *Hooks) execute(w util.BufWriter, tmpl *template.Template, data interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute render hook %s: %w", tmpl.Name(), err)
+	}
+	_, _ = w.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+	return nil
+}
+
+// renderChildren 将节点的子节点渲染为 HTML
+func (h *Hooks) renderChildren(source []byte, node ast.Node) (template.HTML, error) {
+	var buf bytes.Buffer
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		if err := h.md.Renderer().Render(&buf, source, c); err != nil {
+			return "", err
+		}
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// page 获取节点所在的文章
+func page(node ast.Node) interface{} {
+	doc := node.OwnerDocument()
+	if doc == nil {
+		return nil
+	}
+	v, _ := doc.AttributeString(string(pageAttr))
+	return v
+}
+
+// attributes 将节点属性转换为模板可用的 map
+func attributes(node ast.Node) map[string]interface{} {
+	attrs := make(map[string]interface{})
+	for _, attr := range node.Attributes() {
+		switch v := attr.Value.(type) {
+		case []byte:
+			attrs[string(attr.Name)] = string(v)
+		default:
+			attrs[string(attr.Name)] = v
+		}
+	}
+	return attrs
+}
+
+// PlainText 提取节点中的纯文本
+func PlainText(node ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch v := n.(type) {
+		case *ast.Text:
+			buf.Write(v.Segment.Value(source))
+			if v.SoftLineBreak() || v.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(v.Value)
+		case *ast.CodeSpan:
+			for c := v.FirstChild(); c != nil; c = c.NextSibling() {
+				if t, ok := c.(*ast.Text); ok {
+					buf.Write(t.Segment.Value(source))
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			buf.Write(v.Label(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return buf.String()
+}
+
+// pageTransformer 将解析上下文中的文章保存到文档节点，供渲染钩子使用
+type pageTransformer struct{}
+
+func (pageTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	if p := pc.Get(shortcode.PageKey); p != nil {
+		doc.SetAttribute(pageAttr, p)
+	}
+}
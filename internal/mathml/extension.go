@@ -0,0 +1,227 @@
+package mathml
+
+import (
+	"bytes"
+
+	"github.com/jiangjiax/stars/internal/mdutil"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindInline 行内公式节点
+var KindInline = ast.NewNodeKind("MathInline")
+
+// KindBlock 块级公式节点
+var KindBlock = ast.NewNodeKind("MathBlock")
+
+// Inline 行内公式 $...$，$$...$$ 写在段落中时也作为行内节点，但按块级公式显示
+type Inline struct {
+	ast.BaseInline
+	TeX     []byte
+	Display bool
+}
+
+// Kind 实现 ast.Node
+func (n *Inline) Kind() ast.NodeKind { return KindInline }
+
+// Dump 实现 ast.Node
+func (n *Inline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TeX": string(n.TeX)}, nil)
+}
+
+// Block 独占多行的块级公式 $$...$$
+type Block struct {
+	ast.BaseBlock
+	closed bool
+}
+
+// Kind 实现 ast.Node
+func (n *Block) Kind() ast.NodeKind { return KindBlock }
+
+// IsRaw 公式内容不作为 markdown 解析
+func (n *Block) IsRaw() bool { return true }
+
+// Dump 实现 ast.Node
+func (n *Block) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+var delimiter = []byte("$$")
+
+// blockParser 解析以 $$ 开头的公式块
+type blockParser struct{}
+
+func (p *blockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p *blockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], delimiter) {
+		return nil, parser.NoChildren
+	}
+
+	node := &Block{}
+	start := segment.Start + pos + len(delimiter)
+	rest := bytes.TrimSpace(line[pos+len(delimiter):])
+	if i := bytes.Index(rest, delimiter); i >= 0 {
+		// 单行的 $$...$$，结束符后不能有其他内容
+		if len(bytes.TrimSpace(rest[i+len(delimiter):])) > 0 {
+			return nil, parser.NoChildren
+		}
+		stop := start + bytes.Index(line[pos+len(delimiter):], delimiter)
+		node.Lines().Append(text.NewSegment(start, stop))
+		node.closed = true
+	} else if len(rest) > 0 {
+		node.Lines().Append(text.NewSegment(start, segment.Stop))
+	}
+
+	mdutil.SkipLine(reader)
+	return node, parser.NoChildren
+}
+
+func (p *blockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	n := node.(*Block)
+	if n.closed {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	if line == nil {
+		return parser.Close
+	}
+
+	if i := bytes.Index(line, delimiter); i >= 0 && len(bytes.TrimSpace(line[i+len(delimiter):])) == 0 {
+		if len(bytes.TrimSpace(line[:i])) > 0 {
+			n.Lines().Append(text.NewSegment(segment.Start, segment.Start+i))
+		}
+		n.closed = true
+		mdutil.SkipLine(reader)
+		return parser.Close
+	}
+
+	n.Lines().Append(segment)
+	mdutil.SkipLine(reader)
+	return parser.Continue | parser.NoChildren
+}
+
+func (p *blockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *blockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *blockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// inlineParser 解析段落中的 $...$ 和 $$...$$
+//
+// 为避免把金额误认为公式，开头的 $ 后和结尾的 $ 前不能是空白，结尾的 $ 后不能紧跟数字
+type inlineParser struct{}
+
+func (p *inlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p *inlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+
+	if bytes.HasPrefix(line, delimiter) {
+		end := bytes.Index(line[2:], delimiter)
+		if end <= 0 {
+			return nil
+		}
+		block.Advance(end + 4)
+		return &Inline{TeX: line[2 : end+2], Display: true}
+	}
+
+	if len(line) < 3 || util.IsSpace(line[1]) {
+		return nil
+	}
+	for i := 2; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '$':
+			// 不符合结尾规则的 $ 说明这里不是公式
+			if util.IsSpace(line[i-1]) || (i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9') {
+				return nil
+			}
+			block.Advance(i + 1)
+			return &Inline{TeX: line[1:i]}
+		}
+	}
+	return nil
+}
+
+// Renderer 将公式节点渲染为 MathML
+type Renderer struct{}
+
+// RegisterFuncs 实现 renderer.NodeRenderer
+func (r *Renderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindInline, r.renderInline)
+	reg.Register(KindBlock, r.renderBlock)
+}
+
+func (r *Renderer) renderInline(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*Inline)
+	_, _ = w.WriteString(`<span class="math-inline">`)
+	writeMath(w, n.TeX, n.Display)
+	_, _ = w.WriteString("</span>")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *Renderer) renderBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*Block)
+	var tex bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		tex.Write(line.Value(source))
+	}
+	_, _ = w.WriteString(`<div class="math">`)
+	writeMath(w, tex.Bytes(), true)
+	_, _ = w.WriteString("</div>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// writeMath 输出 MathML，公式有误时原样输出 LaTeX 源码
+func writeMath(w util.BufWriter, tex []byte, display bool) {
+	out, err := Convert(string(tex), display)
+	if err != nil {
+		_, _ = w.WriteString(`<code class="math-error" title="`)
+		_, _ = w.Write(util.EscapeHTML([]byte(err.Error())))
+		_, _ = w.WriteString(`">`)
+		_, _ = w.Write(util.EscapeHTML(tex))
+		_, _ = w.WriteString("</code>")
+		return
+	}
+	_, _ = w.WriteString(out)
+}
+
+type extension struct{}
+
+// Extension 数学公式扩展，支持 $...$ 行内公式和 $$...$$ 块级公式
+var Extension = &extension{}
+
+// Extend 实现 goldmark.Extender
+func (e *extension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&blockParser{}, 701)),
+		parser.WithInlineParsers(util.Prioritized(&inlineParser{}, 501)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&Renderer{}, 500),
+	))
+}
@@ -0,0 +1,27 @@
+package mathml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestBlockAtEOF(t *testing.T) {
+	// 结束符位于最后一行且没有换行符
+	source := "$$\nx^2\n$$"
+	var buf bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(Extension))
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, `<div class="math"><math`) || !strings.Contains(out, `display="block"`) {
+		t.Errorf("Convert(%q) = %q, want a block MathML element", source, out)
+	}
+	if strings.Contains(out, "$") || strings.Contains(out, "<p>") {
+		t.Errorf("Convert(%q) = %q, closing delimiter leaked into the output", source, out)
+	}
+}
@@ -0,0 +1,688 @@
+// Package mathml 在构建时将 LaTeX 公式转换为 MathML，页面无需加载任何脚本
+package mathml
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// node 转换后的 MathML 元素
+type node struct {
+	xml    string
+	limits bool // 上下标是否显示在正上方和正下方
+}
+
+// converter 递归下降解析 LaTeX 公式
+type converter struct {
+	src     []rune
+	pos     int
+	display bool
+	variant string // 当前字体，如 \mathbb 中的 double-struck
+}
+
+// Convert 将 LaTeX 公式转换为 MathML，display 为 true 时输出块级公式
+func Convert(tex string, display bool) (string, error) {
+	c := &converter{src: []rune(tex), display: display}
+	body, err := c.parseTable("")
+	if err != nil {
+		return "", err
+	}
+	if c.pos < len(c.src) {
+		return "", fmt.Errorf("unexpected %q at position %d", string(c.src[c.pos:]), c.pos)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<math xmlns="http://www.w3.org/1998/Math/MathML"`)
+	if display {
+		sb.WriteString(` display="block"`)
+	}
+	sb.WriteString(`><semantics>`)
+	sb.WriteString(body)
+	sb.WriteString(`<annotation encoding="application/x-tex">`)
+	sb.WriteString(html.EscapeString(strings.TrimSpace(tex)))
+	sb.WriteString(`</annotation></semantics></math>`)
+	return sb.String(), nil
+}
+
+// parseTable 解析由 & 和 \\ 分隔的表格，env 为空时表示公式顶层
+func (c *converter) parseTable(env string) (string, error) {
+	var rows [][]string
+	var cells []string
+	for {
+		items, err := c.parseRow()
+		if err != nil {
+			return "", err
+		}
+		cells = append(cells, mrow(items))
+
+		switch {
+		case c.peekCommand("\\"):
+			c.pos += 2
+			// 忽略 \\[2pt] 这样的行距参数
+			if c.peek() == '[' {
+				if _, err := c.readBracket(); err != nil {
+					return "", err
+				}
+			}
+			rows = append(rows, cells)
+			cells = nil
+		case c.peek() == '&':
+			c.pos++
+		default:
+			rows = append(rows, cells)
+			if c.peek() == '}' {
+				return "", fmt.Errorf("unbalanced '}' at position %d", c.pos)
+			}
+			if c.peekCommand("right") {
+				return "", fmt.Errorf(`\right without \left`)
+			}
+			if c.peekCommand("end") && env == "" {
+				return "", fmt.Errorf(`\end without \begin`)
+			}
+			return c.table(env, rows), nil
+		}
+	}
+}
+
+// table 输出 mtable，只有一个单元格时直接输出内容
+func (c *converter) table(env string, rows [][]string) string {
+	// 去掉 \\ 结尾产生的空行
+	if n := len(rows); n > 1 && len(rows[n-1]) == 1 && rows[n-1][0] == "<mrow></mrow>" {
+		rows = rows[:n-1]
+	}
+	if env == "" && len(rows) == 1 && len(rows[0]) == 1 {
+		return rows[0][0]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<mtable")
+	switch env {
+	case "aligned", "align", "align*", "split", "alignedat":
+		sb.WriteString(` columnalign="right left" columnspacing="0"`)
+	case "cases", "rcases":
+		sb.WriteString(` columnalign="left left"`)
+	}
+	if env == "" || strings.HasPrefix(env, "gather") || strings.HasPrefix(env, "align") || env == "split" {
+		sb.WriteString(` displaystyle="true"`)
+	}
+	sb.WriteString(">")
+	for _, row := range rows {
+		sb.WriteString("<mtr>")
+		for _, cell := range row {
+			sb.WriteString("<mtd>")
+			sb.WriteString(cell)
+			sb.WriteString("</mtd>")
+		}
+		sb.WriteString("</mtr>")
+	}
+	sb.WriteString("</mtable>")
+	return sb.String()
+}
+
+// parseRow 解析一行公式，遇到 }、&、\\、\right、\end 或结尾时停止
+func (c *converter) parseRow() ([]string, error) {
+	var items []string
+	for {
+		c.skipSpaces()
+		if c.pos >= len(c.src) {
+			return items, nil
+		}
+		switch r := c.peek(); {
+		case r == '}' || r == '&':
+			return items, nil
+		case c.peekCommand("\\") || c.peekCommand("right") || c.peekCommand("end"):
+			return items, nil
+		}
+
+		n, err := c.parseAtom(false)
+		if err != nil {
+			return nil, err
+		}
+		if n == nil {
+			continue
+		}
+		n, err = c.parseScripts(n)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, n.xml)
+	}
+}
+
+// parseScripts 解析原子之后的上标、下标和撇号
+func (c *converter) parseScripts(base *node) (*node, error) {
+	var sub, sup string
+	primes := ""
+	for {
+		c.skipSpaces()
+		switch c.peek() {
+		case '\'':
+			c.pos++
+			primes += "′"
+			continue
+		case '\\':
+			if c.peekCommand("limits") || c.peekCommand("nolimits") {
+				base.limits = c.readCommand() == "limits"
+				continue
+			}
+		case '^', '_':
+			op := c.peek()
+			c.pos++
+			arg, err := c.parseArg()
+			if err != nil {
+				return nil, err
+			}
+			if op == '^' {
+				if sup != "" {
+					return nil, fmt.Errorf("double superscript")
+				}
+				sup = arg
+			} else {
+				if sub != "" {
+					return nil, fmt.Errorf("double subscript")
+				}
+				sub = arg
+			}
+			continue
+		}
+		break
+	}
+
+	if primes != "" {
+		items := []string{"<mo>" + primes + "</mo>"}
+		if sup != "" {
+			items = append(items, sup)
+		}
+		sup = mrow(items)
+	}
+	if sub == "" && sup == "" {
+		return base, nil
+	}
+
+	under, over := "msub", "msup"
+	both := "msubsup"
+	if base.limits {
+		under, over, both = "munder", "mover", "munderover"
+	}
+	switch {
+	case sub != "" && sup != "":
+		return &node{xml: "<" + both + ">" + base.xml + sub + sup + "</" + both + ">"}, nil
+	case sub != "":
+		return &node{xml: "<" + under + ">" + base.xml + sub + "</" + under + ">"}, nil
+	default:
+		return &node{xml: "<" + over + ">" + base.xml + sup + "</" + over + ">"}, nil
+	}
+}
+
+// parseArg 解析命令参数：{...} 分组或单个符号
+func (c *converter) parseArg() (string, error) {
+	c.skipSpaces()
+	if c.pos >= len(c.src) {
+		return "", fmt.Errorf("missing argument at end of formula")
+	}
+	n, err := c.parseAtom(true)
+	if err != nil {
+		return "", err
+	}
+	if n == nil {
+		return "<mrow></mrow>", nil
+	}
+	return n.xml, nil
+}
+
+// parseGroup 解析 {...} 中的内容
+func (c *converter) parseGroup() (string, error) {
+	c.pos++ // {
+	items, err := c.parseRow()
+	if err != nil {
+		return "", err
+	}
+	if c.peek() != '}' {
+		return "", fmt.Errorf("missing '}'")
+	}
+	c.pos++
+	return mrow(items), nil
+}
+
+// parseAtom 解析一个原子，single 为 true 时数字只读取一位
+func (c *converter) parseAtom(single bool) (*node, error) {
+	r := c.peek()
+	switch {
+	case r == '{':
+		xml, err := c.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return &node{xml: xml}, nil
+	case r == '\\':
+		return c.parseCommand()
+	case r == '}' || r == '&':
+		return nil, fmt.Errorf("unexpected %q at position %d", r, c.pos)
+	case r == '^' || r == '_':
+		// 没有底数的上下标，如 ^{14}C
+		return &node{xml: "<mrow></mrow>"}, nil
+	case r == '~':
+		c.pos++
+		return &node{xml: `<mspace width="0.25em"/>`}, nil
+	case unicode.IsDigit(r):
+		start := c.pos
+		c.pos++
+		if !single {
+			for c.pos < len(c.src) && (unicode.IsDigit(c.src[c.pos]) ||
+				(c.src[c.pos] == '.' && c.pos+1 < len(c.src) && unicode.IsDigit(c.src[c.pos+1]))) {
+				c.pos++
+			}
+		}
+		return &node{xml: "<mn>" + c.styled(string(c.src[start:c.pos])) + "</mn>"}, nil
+	case unicode.IsLetter(r):
+		c.pos++
+		if c.variant == "normal" {
+			return &node{xml: `<mi mathvariant="normal">` + html.EscapeString(string(r)) + "</mi>"}, nil
+		}
+		return &node{xml: "<mi>" + c.styled(string(r)) + "</mi>"}, nil
+	}
+
+	c.pos++
+	switch r {
+	case '-':
+		return mo("−"), nil
+	case '*':
+		return mo("∗"), nil
+	case '\'':
+		return mo("′"), nil
+	}
+	return mo(string(r)), nil
+}
+
+// parseCommand 解析以反斜杠开头的命令
+func (c *converter) parseCommand() (*node, error) {
+	name := c.readCommand()
+
+	if s, ok := greek[name]; ok {
+		return &node{xml: "<mi>" + c.styled(s) + "</mi>"}, nil
+	}
+	if s, ok := upperGreek[name]; ok {
+		return &node{xml: `<mi mathvariant="normal">` + s + "</mi>"}, nil
+	}
+	if s, ok := operators[name]; ok {
+		return mo(s), nil
+	}
+	if s, ok := largeOperators[name]; ok {
+		return &node{
+			xml:    `<mo largeop="true" movablelimits="false">` + s + "</mo>",
+			limits: c.display && !integrals[name],
+		}, nil
+	}
+	if limits, ok := functions[name]; ok {
+		return &node{xml: "<mi>" + name + "</mi>", limits: limits && c.display}, nil
+	}
+	if width, ok := spaces[name]; ok {
+		return &node{xml: `<mspace width="` + width + `"/>`}, nil
+	}
+	if ignored[name] {
+		return nil, nil
+	}
+	if a, ok := accents[name]; ok {
+		arg, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		brace := name == "overbrace" || name == "underbrace"
+		stretchy := strings.HasPrefix(name, "wide") || strings.HasPrefix(name, "over") || strings.HasPrefix(name, "under")
+		mark := `<mo stretchy="` + fmt.Sprint(stretchy) + `">` + html.EscapeString(a.mark) + "</mo>"
+		if a.under {
+			return &node{xml: `<munder accentunder="true">` + arg + mark + "</munder>", limits: brace}, nil
+		}
+		return &node{xml: `<mover accent="true">` + arg + mark + "</mover>", limits: brace}, nil
+	}
+	if variant, ok := fonts[name]; ok && !strings.HasPrefix(name, "text") {
+		saved := c.variant
+		c.variant = variant
+		arg, err := c.parseArg()
+		c.variant = saved
+		if err != nil {
+			return nil, err
+		}
+		return &node{xml: arg}, nil
+	}
+
+	switch name {
+	case "{", "}", "|", "%", "$", "#", "&", "_", "/":
+		if name == "|" {
+			return mo("‖"), nil
+		}
+		return mo(name), nil
+	case "frac", "dfrac", "tfrac", "cfrac":
+		num, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		den, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return &node{xml: "<mfrac>" + num + den + "</mfrac>"}, nil
+	case "binom", "dbinom", "tbinom":
+		top, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		bottom, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return &node{xml: `<mrow><mo>(</mo><mfrac linethickness="0">` + top + bottom + `</mfrac><mo>)</mo></mrow>`}, nil
+	case "sqrt":
+		var index string
+		if c.skipSpaces(); c.peek() == '[' {
+			inner, err := c.readBracket()
+			if err != nil {
+				return nil, err
+			}
+			sub := &converter{src: []rune(inner), display: c.display}
+			if index, err = sub.parseTable(""); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		if index != "" {
+			return &node{xml: "<mroot>" + arg + index + "</mroot>"}, nil
+		}
+		return &node{xml: "<msqrt>" + arg + "</msqrt>"}, nil
+	case "overset", "stackrel", "underset":
+		mark, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		base, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		if name == "underset" {
+			return &node{xml: "<munder>" + base + mark + "</munder>"}, nil
+		}
+		return &node{xml: "<mover>" + base + mark + "</mover>"}, nil
+	case "text", "mbox", "textrm", "textbf", "textit", "mathop", "operatorname":
+		text, err := c.readTextArg()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "operatorname", "mathop":
+			return &node{xml: "<mi>" + html.EscapeString(text) + "</mi>"}, nil
+		case "text", "mbox", "textrm":
+			return &node{xml: "<mtext>" + html.EscapeString(text) + "</mtext>"}, nil
+		}
+		return &node{xml: `<mtext mathvariant="` + fonts[name] + `">` + html.EscapeString(text) + "</mtext>"}, nil
+	case "left":
+		return c.parseFenced()
+	case "begin":
+		return c.parseEnvironment()
+	case "not":
+		c.skipSpaces()
+		if c.peek() == '=' {
+			c.pos++
+			return mo("≠"), nil
+		}
+		n, err := c.parseAtom(true)
+		if err != nil || n == nil {
+			return n, err
+		}
+		return &node{xml: strings.Replace(n.xml, "</mo>", "̸</mo>", 1)}, nil
+	case "bmod":
+		return mo("mod"), nil
+	case "pmod":
+		arg, err := c.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return &node{xml: `<mrow><mo>(</mo><mo lspace="0">mod</mo>` + arg + `<mo>)</mo></mrow>`}, nil
+	}
+
+	return &node{xml: "<merror><mtext>\\" + html.EscapeString(name) + "</mtext></merror>"}, nil
+}
+
+// parseFenced 解析 \left ... \right
+func (c *converter) parseFenced() (*node, error) {
+	open, err := c.readDelimiter()
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.parseRow()
+	if err != nil {
+		return nil, err
+	}
+	if !c.peekCommand("right") {
+		return nil, fmt.Errorf(`missing \right`)
+	}
+	c.readCommand()
+	closing, err := c.readDelimiter()
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<mrow>")
+	if open != "" {
+		sb.WriteString(`<mo fence="true" stretchy="true">` + html.EscapeString(open) + "</mo>")
+	}
+	sb.WriteString(strings.Join(items, ""))
+	if closing != "" {
+		sb.WriteString(`<mo fence="true" stretchy="true">` + html.EscapeString(closing) + "</mo>")
+	}
+	sb.WriteString("</mrow>")
+	return &node{xml: sb.String()}, nil
+}
+
+// readDelimiter 读取 \left 和 \right 后的括号，"." 表示不显示
+func (c *converter) readDelimiter() (string, error) {
+	c.skipSpaces()
+	if c.pos >= len(c.src) {
+		return "", fmt.Errorf("missing delimiter")
+	}
+	if c.peek() == '\\' {
+		name := c.readCommand()
+		switch name {
+		case "{", "}":
+			return name, nil
+		case "|":
+			return "‖", nil
+		}
+		if s, ok := operators[name]; ok {
+			return s, nil
+		}
+		return "", fmt.Errorf(`invalid delimiter \%s`, name)
+	}
+	r := c.src[c.pos]
+	c.pos++
+	if r == '.' {
+		return "", nil
+	}
+	return string(r), nil
+}
+
+// parseEnvironment 解析 \begin{env} ... \end{env}
+func (c *converter) parseEnvironment() (*node, error) {
+	env, err := c.readTextArg()
+	if err != nil {
+		return nil, err
+	}
+
+	// array 和 alignedat 的列参数不影响输出
+	if env == "array" || env == "alignedat" {
+		if _, err := c.readTextArg(); err != nil {
+			return nil, err
+		}
+	}
+
+	var body string
+	if env == "equation" || env == "equation*" {
+		items, err := c.parseRow()
+		if err != nil {
+			return nil, err
+		}
+		body = mrow(items)
+	} else {
+		if _, ok := matrixFences[env]; !ok {
+			return nil, fmt.Errorf("unsupported environment: %s", env)
+		}
+		if body, err = c.parseTable(env); err != nil {
+			return nil, err
+		}
+	}
+
+	if !c.peekCommand("end") {
+		return nil, fmt.Errorf(`missing \end{%s}`, env)
+	}
+	c.readCommand()
+	end, err := c.readTextArg()
+	if err != nil {
+		return nil, err
+	}
+	if end != env {
+		return nil, fmt.Errorf(`\begin{%s} ended by \end{%s}`, env, end)
+	}
+
+	fences := matrixFences[env]
+	if fences[0] == "" && fences[1] == "" {
+		return &node{xml: body}, nil
+	}
+	var sb strings.Builder
+	sb.WriteString("<mrow>")
+	if fences[0] != "" {
+		sb.WriteString(`<mo fence="true" stretchy="true">` + fences[0] + "</mo>")
+	}
+	sb.WriteString(body)
+	if fences[1] != "" {
+		sb.WriteString(`<mo fence="true" stretchy="true">` + fences[1] + "</mo>")
+	}
+	sb.WriteString("</mrow>")
+	return &node{xml: sb.String()}, nil
+}
+
+// readCommand 读取反斜杠后的命令名
+func (c *converter) readCommand() string {
+	c.pos++ // \
+	if c.pos >= len(c.src) {
+		return ""
+	}
+	start := c.pos
+	if !isASCIILetter(c.src[c.pos]) {
+		c.pos++
+		return string(c.src[start:c.pos])
+	}
+	for c.pos < len(c.src) && isASCIILetter(c.src[c.pos]) {
+		c.pos++
+	}
+	return string(c.src[start:c.pos])
+}
+
+// readTextArg 读取 {...} 中的原始文本
+func (c *converter) readTextArg() (string, error) {
+	c.skipSpaces()
+	if c.peek() != '{' {
+		if c.pos < len(c.src) {
+			c.pos++
+			return string(c.src[c.pos-1]), nil
+		}
+		return "", fmt.Errorf("missing argument")
+	}
+	depth := 0
+	start := c.pos + 1
+	for i := c.pos; i < len(c.src); i++ {
+		switch c.src[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				c.pos = i + 1
+				return string(c.src[start:i]), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("missing '}'")
+}
+
+// readBracket 读取 [...] 中的内容
+func (c *converter) readBracket() (string, error) {
+	depth := 0
+	start := c.pos + 1
+	for i := c.pos + 1; i < len(c.src); i++ {
+		switch c.src[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ']':
+			if depth == 0 {
+				c.pos = i + 1
+				return string(c.src[start:i]), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("missing ']'")
+}
+
+func (c *converter) peek() rune {
+	if c.pos >= len(c.src) {
+		return 0
+	}
+	return c.src[c.pos]
+}
+
+// peekCommand 判断当前位置是否为指定命令
+func (c *converter) peekCommand(name string) bool {
+	if c.peek() != '\\' {
+		return false
+	}
+	end := c.pos + 1 + len([]rune(name))
+	if end > len(c.src) || string(c.src[c.pos+1:end]) != name {
+		return false
+	}
+	// \right 不能匹配 \rightarrow
+	return !isASCIILetter([]rune(name)[0]) || end >= len(c.src) || !isASCIILetter(c.src[end])
+}
+
+func (c *converter) skipSpaces() {
+	for c.pos < len(c.src) && unicode.IsSpace(c.src[c.pos]) {
+		c.pos++
+	}
+}
+
+// styled 按当前字体将字母和数字转换为数学字母数字符号
+func (c *converter) styled(s string) string {
+	if c.variant == "" || c.variant == "normal" {
+		return html.EscapeString(s)
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		sb.WriteString(html.EscapeString(string(mathAlpha(r, c.variant))))
+	}
+	return sb.String()
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func mo(s string) *node {
+	return &node{xml: "<mo>" + html.EscapeString(s) + "</mo>"}
+}
+
+// mrow 将多个元素组合为一个
+func mrow(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return "<mrow>" + strings.Join(items, "") + "</mrow>"
+}
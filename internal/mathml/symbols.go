@@ -0,0 +1,160 @@
+package mathml
+
+// 希腊字母
+var greek = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ",
+	"varepsilon": "ε", "zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ",
+	"iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ", "nu": "ν",
+	"xi": "ξ", "omicron": "ο", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ",
+	"phi": "ϕ", "varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"ell": "ℓ", "hbar": "ℏ", "imath": "ı", "jmath": "ȷ", "aleph": "ℵ",
+	"wp": "℘", "Re": "ℜ", "Im": "ℑ", "partial": "∂", "infty": "∞",
+	"nabla": "∇", "emptyset": "∅", "varnothing": "∅", "forall": "∀", "exists": "∃",
+	"nexists": "∄", "neg": "¬", "lnot": "¬", "top": "⊤", "bot": "⊥",
+	"angle": "∠", "triangle": "△", "prime": "′", "degree": "°",
+}
+
+// 大写希腊字母，使用直立字体
+var upperGreek = map[string]string{
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ",
+	"Pi": "Π", "Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ",
+	"Omega": "Ω",
+}
+
+// 运算符和关系符
+var operators = map[string]string{
+	"pm": "±", "mp": "∓", "times": "×", "div": "÷", "cdot": "⋅",
+	"ast": "∗", "star": "⋆", "circ": "∘", "bullet": "∙", "oplus": "⊕",
+	"ominus": "⊖", "otimes": "⊗", "oslash": "⊘", "odot": "⊙", "cap": "∩",
+	"cup": "∪", "setminus": "∖", "wedge": "∧", "land": "∧", "vee": "∨",
+	"lor": "∨", "sqcap": "⊓", "sqcup": "⊔", "uplus": "⊎", "dagger": "†",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠",
+	"ne": "≠", "ll": "≪", "gg": "≫", "approx": "≈", "equiv": "≡",
+	"sim": "∼", "simeq": "≃", "cong": "≅", "propto": "∝", "prec": "≺",
+	"succ": "≻", "preceq": "⪯", "succeq": "⪰", "subset": "⊂", "supset": "⊃",
+	"subseteq": "⊆", "supseteq": "⊇", "in": "∈", "notin": "∉", "ni": "∋",
+	"perp": "⊥", "parallel": "∥", "mid": "∣", "vdash": "⊢", "models": "⊨",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "gets": "←", "leftrightarrow": "↔",
+	"Rightarrow": "⇒", "Leftarrow": "⇐", "Leftrightarrow": "⇔", "implies": "⟹", "iff": "⟺",
+	"mapsto": "↦", "longrightarrow": "⟶", "longleftarrow": "⟵", "uparrow": "↑", "downarrow": "↓",
+	"nearrow": "↗", "searrow": "↘", "hookrightarrow": "↪", "therefore": "∴", "because": "∵",
+	"ldots": "…", "dots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱",
+	"colon": ":", "vert": "|", "Vert": "‖", "lvert": "|", "rvert": "|",
+	"lVert": "‖", "rVert": "‖", "langle": "⟨", "rangle": "⟩", "lfloor": "⌊",
+	"rfloor": "⌋", "lceil": "⌈", "rceil": "⌉", "lbrace": "{", "rbrace": "}",
+	"backslash": "\\",
+}
+
+// 大型运算符，上下标显示在上下方
+var largeOperators = map[string]string{
+	"sum": "∑", "prod": "∏", "coprod": "∐", "int": "∫", "iint": "∬",
+	"iiint": "∭", "oint": "∮", "bigcup": "⋃", "bigcap": "⋂", "bigoplus": "⨁",
+	"bigotimes": "⨂", "bigvee": "⋁", "bigwedge": "⋀", "bigsqcup": "⨆",
+}
+
+// 积分号的上下标仍然放在右侧
+var integrals = map[string]bool{
+	"int": true, "iint": true, "iiint": true, "oint": true,
+}
+
+// 函数名，上下标显示在下方的标记为 true
+var functions = map[string]bool{
+	"sin": false, "cos": false, "tan": false, "cot": false, "sec": false,
+	"csc": false, "arcsin": false, "arccos": false, "arctan": false, "sinh": false,
+	"cosh": false, "tanh": false, "coth": false, "log": false, "ln": false,
+	"lg": false, "exp": false, "deg": false, "dim": false, "arg": false,
+	"ker": false, "hom": false, "det": true, "gcd": true, "lim": true,
+	"liminf": true, "limsup": true, "max": true, "min": true, "sup": true,
+	"inf": true, "Pr": true,
+}
+
+// 重音符号，值为符号以及是否放在下方
+var accents = map[string]struct {
+	mark  string
+	under bool
+}{
+	"hat": {"^", false}, "widehat": {"^", false}, "bar": {"¯", false},
+	"overline": {"¯", false}, "vec": {"→", false}, "overrightarrow": {"→", false},
+	"overleftarrow": {"←", false}, "dot": {"˙", false}, "ddot": {"¨", false},
+	"tilde": {"~", false}, "widetilde": {"~", false}, "check": {"ˇ", false},
+	"breve": {"˘", false}, "acute": {"´", false}, "grave": {"`", false},
+	"underline": {"_", true}, "overbrace": {"⏞", false}, "underbrace": {"⏟", true},
+}
+
+// 字体命令对应的 mathvariant
+var fonts = map[string]string{
+	"mathrm": "normal", "mathbf": "bold", "mathit": "italic", "mathsf": "sans-serif",
+	"mathtt": "monospace", "mathbb": "double-struck", "mathcal": "script",
+	"mathscr": "script", "mathfrak": "fraktur", "boldsymbol": "bold-italic",
+	"bm": "bold-italic", "textbf": "bold", "textit": "italic", "textrm": "normal",
+}
+
+// 间距命令
+var spaces = map[string]string{
+	",": "0.1667em", ":": "0.2222em", ">": "0.2222em", ";": "0.2778em",
+	" ": "0.25em", "quad": "1em", "qquad": "2em", "enspace": "0.5em",
+	"thinspace": "0.1667em", "!": "-0.1667em", "negthinspace": "-0.1667em",
+}
+
+// 环境的左右括号
+var matrixFences = map[string][2]string{
+	"matrix": {"", ""}, "smallmatrix": {"", ""}, "pmatrix": {"(", ")"},
+	"bmatrix": {"[", "]"}, "Bmatrix": {"{", "}"}, "vmatrix": {"|", "|"},
+	"Vmatrix": {"‖", "‖"}, "cases": {"{", ""}, "rcases": {"", "}"},
+	"aligned": {"", ""}, "align": {"", ""}, "align*": {"", ""},
+	"gathered": {"", ""}, "gather": {"", ""}, "gather*": {"", ""},
+	"split": {"", ""}, "array": {"", ""}, "alignedat": {"", ""},
+}
+
+// 被忽略的样式命令
+var ignored = map[string]bool{
+	"displaystyle": true, "textstyle": true, "scriptstyle": true,
+	"limits": true, "nolimits": true, "nonumber": true, "notag": true,
+	"big": true, "Big": true, "bigg": true, "Bigg": true,
+	"bigl": true, "bigr": true, "Bigl": true, "Bigr": true,
+	"biggl": true, "biggr": true, "Biggl": true, "Biggr": true,
+}
+
+// 各字体中大写 A、小写 a 和数字 0 在数学字母数字符号区的起始码位
+var alphaBase = map[string][3]rune{
+	"bold":          {0x1D400, 0x1D41A, 0x1D7CE},
+	"italic":        {0x1D434, 0x1D44E, 0},
+	"bold-italic":   {0x1D468, 0x1D482, 0x1D7CE},
+	"script":        {0x1D49C, 0x1D4B6, 0},
+	"fraktur":       {0x1D504, 0x1D51E, 0},
+	"double-struck": {0x1D538, 0x1D552, 0x1D7D8},
+	"sans-serif":    {0x1D5A0, 0x1D5BA, 0x1D7E2},
+	"monospace":     {0x1D670, 0x1D68A, 0x1D7F6},
+}
+
+// 已经在字母式符号区定义的字符，在数学字母数字符号区中留空
+var alphaHoles = map[string]map[rune]rune{
+	"italic": {'h': 'ℎ'},
+	"script": {
+		'B': 'ℬ', 'E': 'ℰ', 'F': 'ℱ', 'H': 'ℋ', 'I': 'ℐ', 'L': 'ℒ',
+		'M': 'ℳ', 'R': 'ℛ', 'e': 'ℯ', 'g': 'ℊ', 'o': 'ℴ',
+	},
+	"fraktur":       {'C': 'ℭ', 'H': 'ℌ', 'I': 'ℑ', 'R': 'ℜ', 'Z': 'ℨ'},
+	"double-struck": {'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ'},
+}
+
+// mathAlpha 将 ASCII 字母和数字转换为指定字体的数学字母数字符号
+func mathAlpha(r rune, variant string) rune {
+	if hole, ok := alphaHoles[variant][r]; ok {
+		return hole
+	}
+	base, ok := alphaBase[variant]
+	if !ok {
+		return r
+	}
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return base[0] + r - 'A'
+	case r >= 'a' && r <= 'z':
+		return base[1] + r - 'a'
+	case r >= '0' && r <= '9' && base[2] != 0:
+		return base[2] + r - '0'
+	}
+	return r
+}
@@ -0,0 +1,15 @@
+// Package mdutil goldmark 扩展共用的解析辅助函数
+package mdutil
+
+import "github.com/yuin/goldmark/text"
+
+// SkipLine 跳过当前行的剩余内容，行尾的换行符留给下一个块解析器。
+// 文件最后一行没有换行符时跳过整行，否则结束标记的最后一个字符会作为段落留在正文中
+func SkipLine(reader text.Reader) {
+	line, segment := reader.PeekLine()
+	n := segment.Len()
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		n--
+	}
+	reader.Advance(n)
+}
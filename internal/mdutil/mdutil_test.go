@@ -0,0 +1,30 @@
+package mdutil
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark/text"
+)
+
+func TestSkipLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string // SkipLine 之后 PeekLine 得到的内容
+	}{
+		{"line with newline", ":::\nnext\n", "\n"},
+		{"last line with newline", ":::\n", "\n"},
+		{"last line without newline", ":::", ""},
+		{"empty line", "\nnext", "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := text.NewReader([]byte(tt.source))
+			SkipLine(reader)
+			line, _ := reader.PeekLine()
+			if string(line) != tt.want {
+				t.Errorf("after SkipLine(%q) next line = %q, want %q", tt.source, line, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Package ogimage 为文章生成 1200×630 的分享图片（og:image），
+// 包含标题、系列、作者头像、站点名称和 NFT 标记
+package ogimage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // 注册 GIF 解码器
+	_ "image/jpeg" // 注册 JPEG 解码器
+	"image/png"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/fonts"
+	"github.com/jiangjiax/stars/internal/post"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+const (
+	Width    = 1200     // 图片宽度
+	Height   = 630      // 图片高度
+	FileName = "og.png" // 分享图片的文件名，位于文章地址下
+)
+
+// Card 分享图片的内容
+type Card struct {
+	Title    string
+	Series   string
+	SiteName string
+	Author   string
+	Avatar   string   // 头像地址，只支持 static 下的图片
+	Badges   []string // 右下角的标记，如 NFT、Arweave
+}
+
+// Path 文章分享图片的地址，如 /posts/hello/og.png
+func Path(p *post.Post) string {
+	return p.URL() + "/" + FileName
+}
+
+// FromPost 根据文章和站点配置生成分享图片的内容，
+// 文章指定了作者时使用第一位作者，否则使用站点作者
+func FromPost(p *post.Post, site *config.Config) Card {
+	card := Card{
+		Title:    p.Title,
+		Series:   p.Series,
+		SiteName: site.Title,
+		Author:   site.Author.Name,
+		Avatar:   site.Author.Avatar,
+	}
+	if authors := p.GetAuthors(); len(authors) > 0 {
+		card.Author = authors[0].Name
+		if authors[0].Avatar != "" {
+			card.Avatar = authors[0].Avatar
+		}
+	}
+
+	if v := p.Verification; v != nil {
+		if v.NftContract != "" {
+			card.Badges = append(card.Badges, "NFT")
+		}
+		if v.ArweaveId != "" {
+			card.Badges = append(card.Badges, "Arweave")
+		}
+	}
+	return card
+}
+
+// Renderer 分享图片渲染器，结果按内容哈希缓存在项目的 .cache/og 目录
+type Renderer struct {
+	staticDirs []string
+	cacheDir   string
+	fontKey    string // 使用的字体文件，参与缓存键
+	regular    *opentype.Font
+	bold       *opentype.Font
+}
+
+// New 创建渲染器，字体查找顺序：ogImage.font > 项目和主题 static/fonts 下的 .ttf > 系统字体。
+// 都找不到时使用内置的 Go 字体，此时中文无法显示
+func New(projectDir string, site *config.Config) (*Renderer, error) {
+	r := &Renderer{
+		staticDirs: []string{
+			filepath.Join(projectDir, "static"),
+			filepath.Join(projectDir, "themes", site.Theme, "static"),
+		},
+		cacheDir: filepath.Join(projectDir, ".cache", "og"),
+	}
+
+	regular := fonts.Resolve(projectDir, site.OGImage.Font)
+	if regular == "" {
+		regular = fonts.Find(projectDir, site.Theme)
+	}
+	bold := fonts.Resolve(projectDir, site.OGImage.BoldFont)
+	if bold == "" {
+		bold = regular
+	}
+
+	var err error
+	if regular == "" {
+		log.Printf("Warning: no CJK font found for share images, set ogImage.font in config.yaml to display Chinese characters")
+		r.fontKey = "go"
+		if r.regular, err = opentype.Parse(goregular.TTF); err != nil {
+			return nil, fmt.Errorf("failed to parse font: %w", err)
+		}
+		if r.bold, err = opentype.Parse(gobold.TTF); err != nil {
+			return nil, fmt.Errorf("failed to parse font: %w", err)
+		}
+		return r, nil
+	}
+
+	r.fontKey = regular + "|" + bold
+	if r.regular, err = parseFont(regular); err != nil {
+		return nil, err
+	}
+	if r.bold, err = parseFont(bold); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func parseFont(path string) (*opentype.Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font %s: %w", path, err)
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Render 生成 PNG 图片，内容、头像和字体都没有变化时使用缓存
+func (r *Renderer) Render(card Card) ([]byte, error) {
+	avatar := r.readStatic(card.Avatar)
+
+	meta, _ := json.Marshal(card)
+	key := sha256.New()
+	key.Write(meta)
+	key.Write([]byte(r.fontKey))
+	key.Write(avatar)
+	cacheFile := filepath.Join(r.cacheDir, fmt.Sprintf("%x.png", key.Sum(nil)[:16]))
+	if data, err := os.ReadFile(cacheFile); err == nil {
+		return data, nil
+	}
+
+	img, err := r.draw(card, avatar)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode share image: %w", err)
+	}
+
+	if err := os.MkdirAll(r.cacheDir, 0755); err == nil {
+		if err := os.WriteFile(cacheFile, buf.Bytes(), 0644); err != nil {
+			log.Printf("Warning: failed to write share image cache: %v", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Save 生成图片并写入文件
+func (r *Renderer) Save(card Card, file string) error {
+	data, err := r.Render(card)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write share image: %w", err)
+	}
+	return nil
+}
+
+// readStatic 读取 static 下的文件，地址可以是 /static/a.jpg 或 ../static/a.jpg，读取失败时返回 nil
+func (r *Renderer) readStatic(src string) []byte {
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return nil
+	}
+
+	p := strings.TrimLeft(u.Path, "./")
+	if !strings.HasPrefix(p, "static/") {
+		return nil
+	}
+	rel := path.Clean(strings.TrimPrefix(p, "static/"))
+	if strings.HasPrefix(rel, "../") {
+		return nil
+	}
+
+	for _, dir := range r.staticDirs {
+		if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel))); err == nil {
+			return data
+		}
+	}
+	return nil
+}
+
+// 配色与默认主题一致
+var (
+	colorTop    = color.RGBA{0x0a, 0x19, 0x2f, 0xff}
+	colorBottom = color.RGBA{0x11, 0x22, 0x40, 0xff}
+	colorAccent = color.RGBA{0x64, 0xff, 0xda, 0xff}
+	colorTitle  = color.RGBA{0xe6, 0xf1, 0xff, 0xff}
+	colorText   = color.RGBA{0xcc, 0xd6, 0xf6, 0xff}
+	colorMuted  = color.RGBA{0x88, 0x92, 0xb0, 0xff}
+)
+
+const (
+	padding    = 80
+	avatarSize = 64
+	footerLine = 560 // 作者和标记的基线
+)
+
+// draw 绘制图片：左上角为头像和站点名称，中间为系列和标题，底部为作者和标记
+func (r *Renderer) draw(card Card, avatar []byte) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+
+	// 纵向渐变背景和左侧强调色条
+	for y := 0; y < Height; y++ {
+		c := mix(colorTop, colorBottom, float64(y)/float64(Height-1))
+		draw.Draw(img, image.Rect(0, y, Width, y+1), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	draw.Draw(img, image.Rect(0, 0, 12, Height), image.NewUniform(colorAccent), image.Point{}, draw.Src)
+
+	faces := make(map[float64]font.Face)
+	face := func(f *opentype.Font, size float64) (font.Face, error) {
+		key := size
+		if f == r.bold {
+			key = -size
+		}
+		if face, ok := faces[key]; ok {
+			return face, nil
+		}
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		faces[key] = face
+		return face, nil
+	}
+	defer func() {
+		for _, f := range faces {
+			f.Close()
+		}
+	}()
+
+	// 头像和站点名称
+	x := padding
+	if avatar != nil {
+		if src, _, err := image.Decode(bytes.NewReader(avatar)); err == nil {
+			drawAvatar(img, src, image.Rect(x, 56, x+avatarSize, 56+avatarSize))
+			x += avatarSize + 20
+		}
+	}
+	siteFace, err := face(r.regular, 28)
+	if err != nil {
+		return nil, err
+	}
+	text(img, siteFace, colorText, x, 98, truncate(siteFace, card.SiteName, Width-padding-x))
+
+	// 系列和标题
+	y := 250
+	if card.Series != "" {
+		seriesFace, err := face(r.regular, 30)
+		if err != nil {
+			return nil, err
+		}
+		text(img, seriesFace, colorAccent, padding, 220, truncate(seriesFace, card.Series, Width-2*padding))
+		y = 300
+	}
+
+	// 标题最多三行，放不下时逐步缩小字号，最小字号仍放不下时截断
+	var (
+		titleFace font.Face
+		lines     []string
+		size      float64
+	)
+	for _, size = range []float64{64, 56, 48} {
+		if titleFace, err = face(r.bold, size); err != nil {
+			return nil, err
+		}
+		if lines = wrap(titleFace, card.Title, Width-2*padding, 3); !strings.HasSuffix(lines[len(lines)-1], ellipsis) {
+			break
+		}
+	}
+	for i, line := range lines {
+		text(img, titleFace, colorTitle, padding, y+int(float64(i)*size*1.3), line)
+	}
+
+	// 作者和标记
+	footerFace, err := face(r.regular, 26)
+	if err != nil {
+		return nil, err
+	}
+	right := Width - padding
+	for i := len(card.Badges) - 1; i >= 0; i-- {
+		right = drawBadge(img, footerFace, card.Badges[i], right) - 16
+	}
+	if card.Author != "" {
+		text(img, footerFace, colorMuted, padding, footerLine, truncate(footerFace, card.Author, right-padding))
+	}
+	return img, nil
+}
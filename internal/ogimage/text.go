@@ -0,0 +1,208 @@
+package ogimage
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ellipsis 截断文字时使用的省略号
+const ellipsis = "…"
+
+// mix 按比例混合两种颜色
+func mix(a, b color.RGBA, t float64) color.RGBA {
+	lerp := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 0xff}
+}
+
+// text 在基线 (x, y) 处绘制一行文字
+func text(dst draw.Image, face font.Face, c color.Color, x, y int, s string) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// measure 文字的宽度（像素）
+func measure(face font.Face, s string) int {
+	return font.MeasureString(face, s).Ceil()
+}
+
+// truncate 文字超过 width 时截断并加上省略号
+func truncate(face font.Face, s string, width int) string {
+	if measure(face, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if t := strings.TrimRightFunc(string(runes), unicode.IsSpace) + ellipsis; measure(face, t) <= width {
+			return t
+		}
+	}
+	return ellipsis
+}
+
+// isCJK 中日韩文字和全角标点，可以在任意两个字之间换行
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef)
+}
+
+// tokens 将文字拆分为换行的最小单位：中文按字，英文按单词，空白单独成为一个单位
+func tokens(s string) []string {
+	var (
+		result []string
+		word   []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, string(word))
+			word = word[:0]
+		}
+	}
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			result = append(result, " ")
+		case isCJK(r):
+			flush()
+			result = append(result, string(r))
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	return result
+}
+
+// wrap 将文字按 width 换行，最多 maxLines 行，超出时最后一行以省略号结尾
+func wrap(face font.Face, s string, width, maxLines int) []string {
+	var (
+		lines []string
+		line  string
+	)
+	push := func() {
+		lines = append(lines, strings.TrimSpace(line))
+		line = ""
+	}
+
+	for _, tok := range tokens(strings.TrimSpace(s)) {
+		if tok == " " && line == "" {
+			continue
+		}
+		if measure(face, line+tok) <= width {
+			line += tok
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			push()
+			if tok == " " {
+				continue
+			}
+		}
+		// 单个单词比整行还长时按字符拆分
+		for _, r := range tok {
+			if measure(face, line+string(r)) > width && line != "" {
+				push()
+			}
+			line += string(r)
+		}
+	}
+	if strings.TrimSpace(line) != "" || len(lines) == 0 {
+		push()
+	}
+
+	if len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+	last := lines[maxLines-1]
+	if measure(face, last+ellipsis) <= width {
+		lines[maxLines-1] = last + ellipsis
+	} else {
+		lines[maxLines-1] = truncate(face, last+ellipsis+ellipsis, width)
+	}
+	return lines
+}
+
+// drawAvatar 将头像缩放后以圆形绘制到 rect
+func drawAvatar(dst draw.Image, src image.Image, rect image.Rectangle) {
+	scaled := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	b := src.Bounds()
+	// 裁剪为正方形，避免拉伸
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(b.Min).Add(image.Pt((b.Dx()-side)/2, (b.Dy()-side)/2))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, crop, draw.Src, nil)
+	draw.DrawMask(dst, rect, scaled, image.Point{}, &roundRect{size: rect.Size(), radius: rect.Dx() / 2}, image.Point{}, draw.Over)
+}
+
+// drawBadge 以 right 为右边界绘制标记，返回标记的左边界
+func drawBadge(dst draw.Image, face font.Face, label string, right int) int {
+	const (
+		padX   = 20
+		height = 44
+	)
+	width := measure(face, label) + 2*padX
+	rect := image.Rect(right-width, footerLine-30, right, footerLine-30+height)
+
+	bg := color.NRGBA{colorAccent.R, colorAccent.G, colorAccent.B, 0x26}
+	draw.DrawMask(dst, rect, image.NewUniform(bg), image.Point{}, &roundRect{size: rect.Size(), radius: height / 2}, image.Point{}, draw.Over)
+	text(dst, face, colorAccent, rect.Min.X+padX, footerLine, label)
+	return rect.Min.X
+}
+
+// roundRect 圆角矩形遮罩，radius 为边长一半时为圆形
+type roundRect struct {
+	size   image.Point
+	radius int
+}
+
+func (m *roundRect) ColorModel() color.Model { return color.AlphaModel }
+
+func (m *roundRect) Bounds() image.Rectangle { return image.Rectangle{Max: m.size} }
+
+func (m *roundRect) At(x, y int) color.Color {
+	r := float64(m.radius)
+	// 到最近的圆角圆心的距离，不在圆角区域时为完全不透明
+	cx, cy := float64(x)+0.5, float64(y)+0.5
+	switch {
+	case cx < r:
+	case cx > float64(m.size.X)-r:
+		cx = float64(m.size.X) - cx
+	default:
+		return color.Alpha{0xff}
+	}
+	switch {
+	case cy < r:
+	case cy > float64(m.size.Y)-r:
+		cy = float64(m.size.Y) - cy
+	default:
+		return color.Alpha{0xff}
+	}
+	dx, dy := r-cx, r-cy
+	d := dx*dx + dy*dy
+	switch {
+	case d <= (r-1)*(r-1):
+		return color.Alpha{0xff}
+	case d >= r*r:
+		return color.Alpha{0}
+	default:
+		// 边缘简单抗锯齿
+		return color.Alpha{uint8(255 * (r*r - d) / (r*r - (r-1)*(r-1)))}
+	}
+}
@@ -0,0 +1,132 @@
+package post
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ArchiveYear 某一年的归档，月份按时间倒序排列
+type ArchiveYear struct {
+	Year   int
+	Count  int // 该年的文章数量
+	Months []*ArchiveMonth
+}
+
+// ArchiveMonth 某一月的归档，文章按日期倒序排列
+type ArchiveMonth struct {
+	Year  int
+	Month time.Month
+	Count int // 该月的文章数量
+	Posts []*PostMeta
+}
+
+// URL 年度归档页地址
+func (y *ArchiveYear) URL() string {
+	return fmt.Sprintf("/archives/%d/", y.Year)
+}
+
+// URL 月度归档页地址
+func (m *ArchiveMonth) URL() string {
+	return fmt.Sprintf("/archives/%d/%02d/", m.Year, int(m.Month))
+}
+
+// GetArchives 获取按年、月分组的全部归档（最新的在前）
+func (s *Store) GetArchives() []*ArchiveYear {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	years := make([]int, 0, len(s.archiveIndex))
+	for year := range s.archiveIndex {
+		years = append(years, year)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+
+	archives := make([]*ArchiveYear, 0, len(years))
+	for _, year := range years {
+		archives = append(archives, s.archiveYear(year))
+	}
+	return archives
+}
+
+// GetArchiveYear 获取某一年的归档，该年没有文章时返回 nil
+func (s *Store) GetArchiveYear(year int) *ArchiveYear {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.archiveIndex[year]; !ok {
+		return nil
+	}
+	return s.archiveYear(year)
+}
+
+// GetArchiveMonth 获取某一月的归档，该月没有文章时返回 nil
+func (s *Store) GetArchiveMonth(year int, month time.Month) *ArchiveMonth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	posts, ok := s.archiveIndex[year][month]
+	if !ok {
+		return nil
+	}
+	return newArchiveMonth(year, month, posts)
+}
+
+// GetYearStats 获取每年的文章数量
+func (s *Store) GetYearStats() map[int]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make(map[int]int, len(s.archiveIndex))
+	for year, months := range s.archiveIndex {
+		for _, posts := range months {
+			stats[year] += len(posts)
+		}
+	}
+	return stats
+}
+
+// GetMonthStats 获取每月的文章数量，键为 "2006-01" 格式
+func (s *Store) GetMonthStats() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make(map[string]int)
+	for year, months := range s.archiveIndex {
+		for month, posts := range months {
+			stats[fmt.Sprintf("%d-%02d", year, int(month))] = len(posts)
+		}
+	}
+	return stats
+}
+
+// archiveYear 根据索引生成某一年的归档，调用方需持有锁
+func (s *Store) archiveYear(year int) *ArchiveYear {
+	months := make([]time.Month, 0, len(s.archiveIndex[year]))
+	for month := range s.archiveIndex[year] {
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool {
+		return months[i] > months[j]
+	})
+
+	archive := &ArchiveYear{Year: year}
+	for _, month := range months {
+		m := newArchiveMonth(year, month, s.archiveIndex[year][month])
+		archive.Count += m.Count
+		archive.Months = append(archive.Months, m)
+	}
+	return archive
+}
+
+// newArchiveMonth 创建月度归档，复制文章列表以防止并发修改
+func newArchiveMonth(year int, month time.Month, posts []*PostMeta) *ArchiveMonth {
+	copied := make([]*PostMeta, len(posts))
+	copy(copied, posts)
+	return &ArchiveMonth{
+		Year:  year,
+		Month: month,
+		Count: len(copied),
+		Posts: copied,
+	}
+}
@@ -0,0 +1,109 @@
+package post
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Audio 音频文章（播客节目）的音频信息，对应 front matter 中的 audio
+type Audio struct {
+	File     string `yaml:"file"`     // 音频地址，/static/ 下的本地文件或完整地址
+	Duration string `yaml:"duration"` // 时长，如 "45:30"、"1:02:03" 或秒数
+	Size     int64  `yaml:"size"`     // 文件大小（字节），本地文件可以省略
+	Type     string `yaml:"type"`     // MIME 类型，默认根据扩展名判断
+	Episode  int    `yaml:"episode"`  // 集数
+	Season   int    `yaml:"season"`   // 季数
+	Explicit bool   `yaml:"explicit"` // 是否包含不适宜未成年人的内容
+}
+
+// audioTypes 常见音频格式的 MIME 类型，mime 包的内置表中没有其中一些格式
+var audioTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/x-m4a",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/opus",
+	".wav":  "audio/wav",
+	".flac": "audio/flac",
+}
+
+// Seconds 时长的秒数，无法解析时返回 0
+func (a *Audio) Seconds() int {
+	seconds := 0
+	for _, part := range strings.Split(strings.TrimSpace(a.Duration), ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
+
+// Length 格式化的时长，如 45:30 或 1:02:03
+func (a *Audio) Length() string {
+	s := a.Seconds()
+	if s == 0 {
+		return a.Duration
+	}
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s%3600/60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// MIME 音频的 MIME 类型
+func (a *Audio) MIME() string {
+	if a.Type != "" {
+		return a.Type
+	}
+	ext := strings.ToLower(path.Ext(a.File))
+	if t, ok := audioTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "audio/mpeg"
+}
+
+// setAudio 本地音频文件未设置大小时读取文件大小，RSS 的 enclosure 需要这个值
+func (l *Loader) setAudio(post *Post) {
+	audio := post.Audio
+	if audio == nil {
+		return
+	}
+	if audio.File == "" {
+		log.Printf("Warning: audio in %s has no file", post.FilePath)
+		post.Audio = nil
+		return
+	}
+	if audio.Size > 0 {
+		return
+	}
+
+	u, err := url.Parse(audio.File)
+	if err != nil || u.Scheme != "" || !strings.HasPrefix(u.Path, "/static/") {
+		return
+	}
+	rel := filepath.FromSlash(strings.TrimPrefix(u.Path, "/static/"))
+	projectDir := filepath.Dir(l.contentDir)
+	for _, dir := range []string{
+		filepath.Join(projectDir, "static"),
+		filepath.Join(projectDir, "themes", l.site.Theme, "static"),
+	} {
+		if info, err := os.Stat(filepath.Join(dir, rel)); err == nil {
+			audio.Size = info.Size()
+			return
+		}
+	}
+	log.Printf("Warning: audio file %s in %s not found", audio.File, post.FilePath)
+}
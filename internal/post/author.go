@@ -0,0 +1,40 @@
+package post
+
+import (
+	"log"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+// GetAuthors 获取文章作者的信息，配置中不存在的作者 ID 会被忽略
+func (p *Post) GetAuthors() []*config.Author {
+	return p.authors
+}
+
+// setAuthors 根据站点配置设置文章的作者信息和作者地址
+//
+// front matter 中已经设置的作者地址不会被覆盖，未设置时使用第一个配置了钱包的作者地址，
+// 都没有时使用站点作者的钱包地址
+func setAuthors(post *Post, site *config.Config) {
+	post.authors = nil
+	wallet := ""
+	for _, id := range post.Authors {
+		author, ok := site.GetAuthor(id)
+		if !ok {
+			log.Printf("Warning: unknown author %q in post %s", id, post.Slug)
+			continue
+		}
+		post.authors = append(post.authors, author)
+		if wallet == "" {
+			wallet = author.WalletAddress
+		}
+	}
+
+	if post.Verification == nil || post.Verification.Author != "" {
+		return
+	}
+	if wallet == "" {
+		wallet = site.Author.WalletAddress
+	}
+	post.Verification.Author = wallet
+}
@@ -0,0 +1,37 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+func TestSetAuthorsWallet(t *testing.T) {
+	site := &config.Config{
+		Author: config.Profile{WalletAddress: "0xsite"},
+		Authors: map[string]*config.Author{
+			"alice": {WalletAddress: "0xalice"},
+			"bob":   {},
+		},
+	}
+	tests := []struct {
+		name    string
+		authors []string
+		wallet  string
+		want    string
+	}{
+		{"front matter", []string{"alice"}, "0xpost", "0xpost"},
+		{"author wallet", []string{"bob", "alice"}, "", "0xalice"},
+		{"site wallet", []string{"bob"}, "", "0xsite"},
+		{"no authors", nil, "", "0xsite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := &Post{Authors: tt.authors, Verification: &config.Verification{Author: tt.wallet}}
+			setAuthors(post, site)
+			if post.Verification.Author != tt.want {
+				t.Errorf("verification.author = %q, want %q", post.Verification.Author, tt.want)
+			}
+		})
+	}
+}
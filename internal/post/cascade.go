@@ -0,0 +1,153 @@
+package post
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/gitinfo"
+	"github.com/jiangjiax/stars/internal/imaging"
+	"github.com/yuin/goldmark"
+	"gopkg.in/yaml.v3"
+)
+
+// Loader 加载 content 目录中的内容文件
+//
+// 文章的 front matter 按以下顺序合并，后面的覆盖前面的：
+// 站点配置中的默认值、从 content 到文章所在目录各级 _index.md 中的 cascade、文章自身的 front matter
+type Loader struct {
+	site       *config.Config
+	contentDir string
+	defaults   map[string]map[string]interface{} // 目录 -> 该目录中内容的默认 front matter
+	git        *gitinfo.Repo                     // 内容所在的 git 仓库，未启用 gitInfo 时为空
+	md         goldmark.Markdown                 // 使用项目短代码和渲染钩子的 markdown 解析器
+	images     *imaging.Processor                // 图片处理器，未启用图片处理时为空
+}
+
+// NewLoader 创建项目的内容加载器，内容位于 projectDir/content，
+// 项目和主题中 layouts/shortcodes 下的短代码和 layouts/_markup 下的渲染钩子对加载的内容生效
+func NewLoader(projectDir string, site *config.Config) *Loader {
+	l := &Loader{
+		site:       site,
+		contentDir: filepath.Join(projectDir, "content"),
+		defaults:   make(map[string]map[string]interface{}),
+	}
+	if site.Imaging.Enabled {
+		l.images = imaging.New(projectDir, site)
+	}
+	l.md = newMarkdown(projectDir, site, l.images)
+	if site.GitInfo.Enabled {
+		repo, err := gitinfo.Open(l.contentDir, site.GitInfo.RepoURL)
+		if err != nil {
+			log.Printf("Warning: gitInfo is enabled but %s is not in a git repository: %v", l.contentDir, err)
+		} else {
+			l.git = repo
+		}
+	}
+	return l
+}
+
+// Load 解析内容文件，合并站点配置和各级 cascade 中的默认值
+func (l *Loader) Load(path string) (*Post, error) {
+	defaults, err := l.defaultsFor(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := parsePost(path, defaults, l.md)
+	if err != nil {
+		return nil, err
+	}
+
+	setAuthors(post, l.site)
+	l.setAudio(post)
+	l.setGitInfo(post)
+	return post, nil
+}
+
+// Images 返回加载器使用的图片处理器，未启用图片处理时为 nil
+func (l *Loader) Images() *imaging.Processor {
+	return l.images
+}
+
+// LoadPosts 加载目录中的所有内容文件，包括草稿，_index.md 除外
+func (l *Loader) LoadPosts(dir string) ([]*Post, error) {
+	var posts []*Post
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" || info.Name() == IndexFile {
+			return nil
+		}
+
+		post, err := l.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse post %s: %w", path, err)
+		}
+		posts = append(posts, post)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// defaultsFor 获取目录中内容的默认 front matter，结果按目录缓存
+func (l *Loader) defaultsFor(dir string) (map[string]interface{}, error) {
+	dir = filepath.Clean(dir)
+	if defaults, ok := l.defaults[dir]; ok {
+		return defaults, nil
+	}
+
+	rel, err := filepath.Rel(l.contentDir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// content 以外的目录只使用站点默认值
+		return l.site.FrontMatterDefaults(), nil
+	}
+
+	var parent map[string]interface{}
+	if rel == "." {
+		parent = l.site.FrontMatterDefaults()
+	} else if parent, err = l.defaultsFor(filepath.Dir(dir)); err != nil {
+		return nil, err
+	}
+
+	cascade, err := readCascade(filepath.Join(dir, IndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := config.MergeParams(parent, cascade)
+	l.defaults[dir] = defaults
+	return defaults, nil
+}
+
+// readCascade 读取 _index.md 中的 cascade，文件不存在时返回 nil
+func readCascade(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	parts := bytes.Split([]byte(normalizeNewlines(string(content))), []byte("---\n"))
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid section index %s: missing front matter", path)
+	}
+
+	var frontMatter struct {
+		Cascade map[string]interface{} `yaml:"cascade"`
+	}
+	if err := yaml.Unmarshal(parts[1], &frontMatter); err != nil {
+		return nil, fmt.Errorf("failed to parse front matter of %s: %w", path, err)
+	}
+	return frontMatter.Cascade, nil
+}
@@ -0,0 +1,28 @@
+package post
+
+import "log"
+
+// setGitInfo 读取文章的提交记录，front matter 中没有 lastmod 时使用最后一次提交的时间
+func (l *Loader) setGitInfo(post *Post) {
+	if l.git == nil {
+		return
+	}
+
+	history, err := l.git.History(post.FilePath)
+	if err != nil {
+		log.Printf("Warning: failed to read git history of %s: %v", post.FilePath, err)
+		return
+	}
+	if len(history) == 0 {
+		return // 尚未提交的文章
+	}
+
+	post.History = history
+	post.GitInfo = history[0]
+	if _, ok := post.frontMatter["lastmod"]; !ok {
+		post.Lastmod = post.GitInfo.AuthorDate
+		if post.Lastmod.Before(post.Date) {
+			post.Lastmod = post.Date
+		}
+	}
+}
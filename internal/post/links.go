@@ -0,0 +1,150 @@
+package post
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Graph 文章之间的 wiki 链接网络，主题可以据此绘制知识图谱
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Links []GraphLink `json:"links"`
+}
+
+// GraphNode 图谱中的文章
+type GraphNode struct {
+	ID     string   `json:"id"` // 文章 slug
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Tags   []string `json:"tags,omitempty"`
+	Series string   `json:"series,omitempty"`
+}
+
+// GraphLink 图谱中的链接，Source 链接到 Target
+type GraphLink struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// ResolveLinks 解析文章中的 wiki 链接并计算每篇文章的 Links 和 Backlinks
+//
+// 文章解析时其他文章还未加载，因此需要在所有文章加入 Store 后调用，
+// 只有包含 wiki 链接的文章会被重新渲染，找不到目标的链接会输出警告
+func (s *Store) ResolveLinks() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	posts := make([]*Post, 0, len(s.posts))
+	for _, p := range s.posts {
+		p.Links = nil
+		p.Backlinks = nil
+		posts = append(posts, p)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Slug < posts[j].Slug
+	})
+
+	lookup := s.linkIndex()
+	resolve := func(target string) (string, string, bool) {
+		p := lookup(target)
+		if p == nil {
+			return "", "", false
+		}
+		return p.URL(), p.Title, true
+	}
+
+	for _, p := range posts {
+		if len(p.wikiTargets) == 0 {
+			continue
+		}
+
+		content, toc, err := render([]byte(p.RawContent), p, resolve)
+		if err != nil {
+			return fmt.Errorf("failed to render post %s: %w", p.Slug, err)
+		}
+		p.Content = template.HTML(content)
+		p.TableOfContents = toc
+
+		seen := make(map[string]bool)
+		for _, target := range p.wikiTargets {
+			linked := lookup(target)
+			if linked == nil {
+				log.Printf("Warning: unresolved wiki link [[%s]] in post %s", target, p.Slug)
+				continue
+			}
+			if linked == p || seen[linked.Slug] {
+				continue
+			}
+			seen[linked.Slug] = true
+			p.Links = append(p.Links, linked.Slug)
+			linked.Backlinks = append(linked.Backlinks, s.metas[p.Slug])
+		}
+	}
+
+	// 反向链接按日期排序（最新的在前）
+	for _, p := range posts {
+		sort.Slice(p.Backlinks, func(i, j int) bool {
+			return p.Backlinks[i].Date.After(p.Backlinks[j].Date)
+		})
+	}
+
+	return nil
+}
+
+// Graph 生成文章链接图谱，需要先调用 ResolveLinks
+func (s *Store) Graph() *Graph {
+	posts := s.List()
+
+	graph := &Graph{
+		Nodes: make([]GraphNode, 0, len(posts)),
+		Links: make([]GraphLink, 0),
+	}
+	for _, p := range posts {
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:     p.Slug,
+			Title:  p.Title,
+			URL:    p.URL(),
+			Tags:   p.Tags,
+			Series: p.Series,
+		})
+		for _, target := range p.Links {
+			graph.Links = append(graph.Links, GraphLink{Source: p.Slug, Target: target})
+		}
+	}
+	return graph
+}
+
+// linkIndex 创建 wiki 链接目标的查找函数，依次按 slug、标题、文件名匹配，不区分大小写
+func (s *Store) linkIndex() func(target string) *Post {
+	bySlug := make(map[string]*Post)
+	byTitle := make(map[string]*Post)
+	byFile := make(map[string]*Post)
+	for _, p := range s.posts {
+		bySlug[linkKey(p.Slug)] = p
+		if p.Title != "" {
+			byTitle[linkKey(p.Title)] = p
+		}
+		if p.FilePath != "" {
+			byFile[linkKey(strings.TrimSuffix(filepath.Base(p.FilePath), filepath.Ext(p.FilePath)))] = p
+		}
+	}
+
+	return func(target string) *Post {
+		key := linkKey(target)
+		for _, index := range []map[string]*Post{bySlug, byTitle, byFile} {
+			if p, ok := index[key]; ok {
+				return p
+			}
+		}
+		return nil
+	}
+}
+
+// linkKey 规范化链接目标
+func linkKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -9,36 +9,31 @@ import (
 	"golang.org/x/crypto/sha3"
 	"gopkg.in/yaml.v3"
 	"html/template"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark-emoji"
-	"github.com/yuin/goldmark-highlighting/v2"
 	"github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	ghtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/jiangjiax/stars/internal/admonition"
 	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/diagram"
+	"github.com/jiangjiax/stars/internal/gitinfo"
+	"github.com/jiangjiax/stars/internal/imaging"
+	"github.com/jiangjiax/stars/internal/markup"
+	"github.com/jiangjiax/stars/internal/mathml"
+	"github.com/jiangjiax/stars/internal/shortcode"
+	"github.com/jiangjiax/stars/internal/wikilink"
 )
 
-var cfg *config.Config
-
-func init() {
-	// 从环境变量或默认路径加载配置
-	var err error
-	cfg, err = config.LoadConfig("config.yaml")
-	if err != nil {
-		// 如果加载失败，使用空配置
-		cfg = &config.Config{}
-	}
-}
-
 // PostMeta 文章元数据
 type PostMeta struct {
 	Title       string    `yaml:"title"`
@@ -59,15 +54,33 @@ type Post struct {
 	Title           string        `yaml:"title"`
 	Slug            string        `yaml:"slug"`
 	Date            time.Time     `yaml:"date"`
+	Lastmod         time.Time     `yaml:"lastmod"` // 最后修改时间，未设置时使用最后一次提交或文件的修改时间
 	Description     string        `yaml:"description"`
+	Canonical       string        `yaml:"canonical"` // 规范地址，转载的文章可以指向原文，为空时使用文章地址
+	Robots          string        `yaml:"robots"`    // robots 指令，如 "noindex"，为空时使用 seo.robots
 	Tags            []string      `yaml:"tags"`
 	Series          string        `yaml:"series"`
 	SeriesOrder     int           `yaml:"seriesOrder"`
+	Authors         []string      `yaml:"authors"` // 作者 ID，对应 config.yaml 中的 authors
+	Section         string        `yaml:"-"`       // 所在分区，博客文章为 posts，独立页面为空
 	Draft           bool          `yaml:"draft"`
 	TableOfContents []*TableOfContentsItem
-	ReadingTime     int                  `yaml:"readingTime"`
-	Verification    *config.Verification `yaml:"verification"`
-	FilePath        string               `yaml:"-"`
+	ReadingTime     int                    `yaml:"readingTime"`
+	Verification    *config.Verification   `yaml:"verification"`
+	Audio           *Audio                 `yaml:"audio"`        // 音频文章（播客节目）的音频，为空时是普通文章
+	Translations    map[string]string      `yaml:"translations"` // 其他语言版本的地址，语言代码 -> 地址
+	FilePath        string                 `yaml:"-"`
+	Links           []string               `yaml:"-"` // wiki 链接指向的文章 slug
+	Backlinks       []*PostMeta            `yaml:"-"` // 通过 wiki 链接引用本文的文章
+	Params          map[string]interface{} `yaml:"-"` // 与站点和分区默认值合并后的完整 front matter
+	OGImage         string                 `yaml:"-"` // 生成的分享图片地址，未启用时为空
+	GitInfo         *gitinfo.Commit        `yaml:"-"` // 最后一次修改文章的提交，未启用 gitInfo 或文章未提交时为空
+	History         []*gitinfo.Commit      `yaml:"-"` // 修改过文章的所有提交，最新的在前
+
+	frontMatter map[string]interface{} // 文件中的 front matter，不含默认值
+	authors     []*config.Author       // 文章作者的信息
+	wikiTargets []string               // 正文中 wiki 链接的目标
+	md          goldmark.Markdown      // 解析文章使用的 markdown 解析器，为空时使用 defaultMarkdown
 }
 
 type TableOfContentsItem struct {
@@ -77,9 +90,13 @@ type TableOfContentsItem struct {
 	Children []*TableOfContentsItem
 }
 
-// 创建一个全局的 markdown 解析器
-var md = goldmark.New(
-	goldmark.WithExtensions(
+// defaultMarkdown 不使用 Loader 解析时的 markdown 解析器，只包含内置的短代码和渲染钩子
+var defaultMarkdown = newMarkdown("", nil, nil)
+
+// newMarkdown 创建 markdown 解析器，短代码和渲染钩子模板从 projectDir 中查找，
+// images 为 nil 时不处理图片
+func newMarkdown(projectDir string, site *config.Config, images *imaging.Processor) goldmark.Markdown {
+	extensions := []goldmark.Extender{
 		extension.GFM,            // GitHub Flavored Markdown
 		extension.Footnote,       // 脚注支持
 		extension.DefinitionList, // 定义列表
@@ -89,21 +106,86 @@ var md = goldmark.New(
 		extension.TaskList,       // 任务列表
 		meta.Meta,                // Front Matter 持
 		emoji.Emoji,              // Emoji 支持
-		highlighting.NewHighlighting( // 代码高亮
-			highlighting.WithStyle("monokai"),
-			highlighting.WithGuessLanguage(true),
+		admonition.Extension,     // 提示块
+		wikilink.Extension,       // wiki 链接
+	}
+
+	if site != nil && site.Markup.Math.Enabled {
+		extensions = append(extensions, mathml.Extension) // 数学公式
+	}
+
+	// 渲染钩子，代码块默认使用代码高亮
+	highlight := config.DefaultHighlight()
+	if site != nil {
+		highlight = site.Markup.Highlight
+	}
+	hooks := markup.New(projectDir, site, markup.Highlighter(highlight))
+
+	// 图表代码块渲染为 SVG，结果缓存在项目的 .cache/diagrams 目录
+	if projectDir != "" {
+		hooks.SetDiagrams(diagram.New(filepath.Join(projectDir, ".cache", "diagrams")))
+	} else {
+		hooks.SetDiagrams(diagram.New(""))
+	}
+
+	// 启用图片处理时，本地图片输出多种宽度和格式
+	if images != nil {
+		hooks.SetImages(images)
+	}
+
+	return goldmark.New(
+		goldmark.WithExtensions(append(extensions,
+			shortcode.New(projectDir, site), // 短代码
+			hooks,
+		)...),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(), // 自动生成标题 ID
+			parser.WithAttribute(),     // 属性支持
 		),
-	),
-	goldmark.WithParserOptions(
-		parser.WithAutoHeadingID(), // 自动生成标题 ID
-		parser.WithAttribute(),     // 属性支持
-	),
-	goldmark.WithRendererOptions(
-		ghtml.WithHardWraps(), // 保留换行
-		ghtml.WithXHTML(),     // 使用 XHTML
-		ghtml.WithUnsafe(),    // 允许原始 HTML
-	),
-)
+		goldmark.WithRendererOptions(
+			ghtml.WithHardWraps(), // 保留换行
+			ghtml.WithXHTML(),     // 使用 XHTML
+			ghtml.WithUnsafe(),    // 允许原始 HTML
+		),
+	)
+}
+
+// render 渲染 Markdown 内容，同时根据文档结构生成目录并记录 wiki 链接的目标，
+// resolve 为 nil 时 wiki 链接均按未解析输出
+func render(source []byte, post *Post, resolve wikilink.Resolver) (string, []*TableOfContentsItem, error) {
+	// 短代码和渲染钩子可以通过 .Page 访问文章
+	context := parser.NewContext()
+	context.Set(shortcode.PageKey, post)
+	if resolve != nil {
+		context.Set(wikilink.ResolverKey, resolve)
+	}
+
+	md := post.md
+	if md == nil {
+		md = defaultMarkdown
+	}
+
+	doc := md.Parser().Parse(text.NewReader(source), parser.WithContext(context))
+	post.wikiTargets = wikiTargets(doc)
+
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, source, doc); err != nil {
+		return "", nil, err
+	}
+	return buf.String(), generateTOC(doc, source), nil
+}
+
+// wikiTargets 收集文档中 wiki 链接的目标
+func wikiTargets(doc ast.Node) []string {
+	var targets []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := n.(*wikilink.Link); ok && entering {
+			targets = append(targets, link.Target)
+		}
+		return ast.WalkContinue, nil
+	})
+	return targets
+}
 
 // ParsePosts 解析指定目录的所有文章
 func ParsePosts(contentDir string) ([]*Post, error) {
@@ -141,8 +223,18 @@ func ParsePosts(contentDir string) ([]*Post, error) {
 	return posts, nil
 }
 
-// ParsePost 解析单个文章文件
+// ParsePost 解析单个文章文件，只合并内置的默认值，
+// 需要合并站点和分区默认值时使用 Loader
 func ParsePost(filePath string) (*Post, error) {
+	return parsePost(filePath, builtinDefaults, nil)
+}
+
+// builtinDefaults 不使用 Loader 解析时的默认 front matter
+var builtinDefaults = new(config.Config).FrontMatterDefaults()
+
+// parsePost 解析单个文章文件，defaults 为合并到 front matter 之前的默认值，
+// md 为 nil 时使用 defaultMarkdown
+func parsePost(filePath string, defaults map[string]interface{}, md goldmark.Markdown) (*Post, error) {
 	// 读取文件内容
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -153,6 +245,8 @@ func ParsePost(filePath string) (*Post, error) {
 	post := &Post{
 		FilePath:   filePath,
 		RawContent: string(content),
+		Section:    PostsSection,
+		md:         md,
 	}
 
 	// 分离 Front Matter 和内容
@@ -161,49 +255,30 @@ func ParsePost(filePath string) (*Post, error) {
 		return nil, fmt.Errorf("invalid post format: missing front matter")
 	}
 
-	// 解析 Front Matter 到 post 结构体
-	if err := yaml.Unmarshal(parts[1], &post); err != nil {
-		return nil, fmt.Errorf("failed to parse front matter: %w", err)
+	if err := decodeFrontMatter(post, parts[1], defaults); err != nil {
+		return nil, err
 	}
 
 	// 设置文章内容
 	post.RawContent = string(bytes.Join(parts[2:], []byte("---\n")))
 
-	// 渲染 Markdown 内容
-	var buf bytes.Buffer
-	context := parser.NewContext()
-	if err := md.Convert([]byte(post.RawContent), &buf, parser.WithContext(context)); err != nil {
-		return nil, fmt.Errorf("failed to render markdown: %w", err)
-	}
-
-	renderedContent := buf.String()
-	post.Content = template.HTML(renderedContent)
-
-	// 从渲染后的 HTML 中提取标题和 ID 来生成目录
-	toc := generateTOCFromHTML(renderedContent)
-	post.TableOfContents = toc
-
-	// 如果没有设置 slug，使用标题生成
-	if post.Slug == "" {
-		post.Slug = slugify(post.Title)
+	// 未设置 lastmod 时使用文件的修改时间，不早于发布时间
+	if post.Lastmod.IsZero() {
+		if info, err := os.Stat(filePath); err == nil {
+			post.Lastmod = info.ModTime()
+		}
 	}
-
-	// 计算阅读时间
-	post.ReadingTime = calculateReadingTime(post.RawContent)
-
-	// 如果没有设置作者地址，从配置文件读取
-	if post.Verification != nil && post.Verification.Author == "" {
-		post.Verification.Author = cfg.Author.WalletAddress
+	if post.Lastmod.Before(post.Date) {
+		post.Lastmod = post.Date
 	}
 
-	if post.Verification == nil {
-		post.Verification = &config.Verification{}
+	if err := renderPost(post); err != nil {
+		return nil, err
 	}
-
 	return post, nil
 }
 
-// ParseContent 解析文章内容
+// ParseContent 解析文章内容，只合并内置的默认值
 func ParseContent(content string) (*Post, error) {
 	// 移除可能的 BOM 头
 	content = removeBOM(content)
@@ -221,46 +296,63 @@ func ParseContent(content string) (*Post, error) {
 		}
 	}
 
-	// 跳过第一个空部分
-	frontMatter := parts[1]
-	content = string(bytes.Join(parts[2:], []byte("---\n")))
-
-	var post Post
-	if err := yaml.Unmarshal(frontMatter, &post); err != nil {
-		return nil, fmt.Errorf("failed to parse front matter: %w", err)
+	post := &Post{Section: PostsSection}
+	if err := decodeFrontMatter(post, parts[1], builtinDefaults); err != nil {
+		return nil, err
 	}
 
-	// 先渲染 Markdown 内容
-	var buf bytes.Buffer
-	context := parser.NewContext()
-	if err := md.Convert([]byte(content), &buf, parser.WithContext(context)); err != nil {
-		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	// 保存原始内容
+	post.RawContent = string(bytes.Join(parts[2:], []byte("---\n")))
+
+	if err := renderPost(post); err != nil {
+		return nil, err
 	}
+	return post, nil
+}
 
-	renderedContent := buf.String()
-	post.Content = template.HTML(renderedContent)
+// decodeFrontMatter 将 front matter 与默认值合并后解析到文章，
+// 没有设置 slug 时使用标题生成，验证信息和 NFT 配置不会为空
+func decodeFrontMatter(post *Post, frontMatter []byte, defaults map[string]interface{}) error {
+	if err := yaml.Unmarshal(frontMatter, &post.frontMatter); err != nil {
+		return fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	post.Params = config.MergeParams(defaults, post.frontMatter)
 
-	// 从渲染后的 HTML 中提取标题和 ID 来生成目录
-	toc := generateTOCFromHTML(renderedContent)
-	post.TableOfContents = toc
+	merged, err := yaml.Marshal(post.Params)
+	if err != nil {
+		return fmt.Errorf("failed to merge front matter: %w", err)
+	}
+	if err := yaml.Unmarshal(merged, post); err != nil {
+		return fmt.Errorf("failed to parse front matter: %w", err)
+	}
 
 	// 如果没有设置 slug，使用标题生成
 	if post.Slug == "" {
 		post.Slug = slugify(post.Title)
 	}
 
-	// 计算阅读时间
-	post.ReadingTime = calculateReadingTime(content)
-
-	// 保存原始内容
-	post.RawContent = content
+	// front matter 中的 verification 或 nft 可能为 null
+	if post.Verification == nil {
+		post.Verification = &config.Verification{}
+	}
+	if post.Verification.NFT == nil {
+		post.Verification.NFT = config.DefaultNFTConfig()
+	}
+	return nil
+}
 
-	// 如果没有设置作者地址，从配置文件读取
-	if post.Verification != nil && post.Verification.Author == "" {
-		post.Verification.Author = cfg.Author.WalletAddress
+// renderPost 渲染文章的 Markdown 内容，生成目录并计算阅读时间
+func renderPost(post *Post) error {
+	renderedContent, toc, err := render([]byte(post.RawContent), post, nil)
+	if err != nil {
+		return fmt.Errorf("failed to render markdown: %w", err)
 	}
+	post.Content = template.HTML(renderedContent)
+	post.TableOfContents = toc
 
-	return &post, nil
+	// 计算阅读时间
+	post.ReadingTime = calculateReadingTime(post.RawContent)
+	return nil
 }
 
 // slugify 将标题转换为 URL 友好的格式
@@ -320,29 +412,35 @@ func max(a, b int) int {
 	return b
 }
 
-// generateTOCFromHTML 从渲染后的 HTML 中提取标题和 ID 来生成目录
-func generateTOCFromHTML(html string) []*TableOfContentsItem {
-	// 匹配 HTML 标题标签及其 ID 属性，使用命名捕获组
-	re := regexp.MustCompile(`<h([1-6])[^>]*id="([^"]+)"[^>]*>(.*?)</h[1-6]>`)
-	matches := re.FindAllStringSubmatch(html, -1)
-
-	if len(matches) == 0 {
-		return nil
-	}
+// generateTOC 遍历文档中的标题节点生成目录
+func generateTOC(doc ast.Node, source []byte) []*TableOfContentsItem {
+	var headings []*ast.Heading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if h, ok := n.(*ast.Heading); ok {
+			headings = append(headings, h)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
 
 	var items []*TableOfContentsItem
 	var stack []*TableOfContentsItem
 	currentLevel := 0
 
-	for _, match := range matches {
-		level, _ := strconv.Atoi(match[1])
-		id := match[2]
-		// 移除标题中可能的 HTML 标签
-		title := regexp.MustCompile(`<[^>]+>`).ReplaceAllString(match[3], "")
+	for _, heading := range headings {
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			continue
+		}
+		idBytes, _ := id.([]byte)
+		level := heading.Level
 
 		item := &TableOfContentsItem{
-			Title: title,
-			ID:    id,
+			Title: markup.PlainText(heading, source),
+			ID:    string(idBytes),
 			Level: level,
 		}
 
@@ -452,17 +550,13 @@ func (p *Post) SaveMetadata() error {
 		return fmt.Errorf("invalid post format")
 	}
 
-	// 更新元数据
-	metadata := map[string]interface{}{
-		"title":        p.Title,
-		"date":         p.Date,
-		"description":  p.Description,
-		"tags":         p.Tags,
-		"slug":         p.Slug,
-		"series":       p.Series,
-		"seriesOrder":  p.SeriesOrder,
-		"verification": p.Verification, // 添加验证信息到元数据
-	}
+	// 更新元数据，只写回文件中原有的 front matter 和内容哈希，
+	// 站点配置和分区 cascade 提供的默认值不会写入文件
+	metadata := config.MergeParams(p.frontMatter, map[string]interface{}{
+		"verification": map[string]interface{}{
+			"contentHash": p.Verification.ContentHash,
+		},
+	})
 
 	// 序列化元数据
 	metadataBytes, err := yaml.Marshal(metadata)
@@ -510,51 +604,10 @@ func (p *Post) calculateContentHash() string {
 	data, _ := json.Marshal(content)
 	hasher.Write(data)
 	hash := hasher.Sum(nil)
-	return "0x" + hex.EncodeToString(hash) + p.Verification.NFT.Version
-}
-
-func (p *Post) parseMetadata(meta map[string]interface{}) error {
-	if verification, ok := meta["verification"].(map[string]interface{}); ok {
-		p.Verification = &config.Verification{}
-
-		// 处理 NFT 配置
-		if nftConfig, ok := verification["nft"].(map[string]interface{}); ok {
-			p.Verification.NFT = &config.NFTConfig{}
-
-			// 解析配置
-			if price, ok := nftConfig["price"].(string); ok {
-				p.Verification.NFT.Price = price
-			}
-			if maxSupply, ok := nftConfig["maxSupply"].(int); ok {
-				p.Verification.NFT.MaxSupply = maxSupply
-			}
-			if royaltyFee, ok := nftConfig["royaltyFee"].(int); ok {
-				p.Verification.NFT.RoyaltyFee = royaltyFee
-			}
-			if onePerAddress, ok := nftConfig["onePerAddress"].(bool); ok {
-				p.Verification.NFT.OnePerAddress = onePerAddress
-			}
-			if version, ok := nftConfig["version"].(string); ok {
-				p.Verification.NFT.Version = version
-			}
-
-			// 验证参数
-			if err := p.Verification.NFT.ValidateNFTConfig(); err != nil {
-				// 记录警告信息
-				log.Printf("Warning: Invalid NFT config in post %s: %v, using default values",
-					p.Slug, err)
-				// 使用默认配置
-				p.Verification.NFT = config.GetDefaultNFTConfig()
-			}
-		}
-
-		// 如果没配置 NFT，使用默认配置
-		if p.Verification.NFT == nil {
-			p.Verification.NFT = config.GetDefaultNFTConfig()
-		}
+	if p.Verification == nil || p.Verification.NFT == nil {
+		return "0x" + hex.EncodeToString(hash)
 	}
-
-	return nil
+	return "0x" + hex.EncodeToString(hash) + p.Verification.NFT.Version
 }
 
 // ContentChanged 检查内容是否变化
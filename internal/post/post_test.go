@@ -0,0 +1,43 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+func TestParseContentDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontMatter string
+		version     string
+	}{
+		{"no verification", "title: a\n", config.DefaultNFTConfig().Version},
+		{"null nft", "title: a\nverification:\n  nft: null\n", config.DefaultNFTConfig().Version},
+		{"front matter nft", "title: a\nverification:\n  nft:\n    version: \"2.0.0\"\n", "2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseContent("---\n" + tt.frontMatter + "---\nbody\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Verification == nil || p.Verification.NFT == nil {
+				t.Fatalf("verification.nft is nil")
+			}
+			if p.Verification.NFT.Version != tt.version {
+				t.Errorf("nft.version = %q, want %q", p.Verification.NFT.Version, tt.version)
+			}
+			if !p.ContentChanged() {
+				t.Errorf("ContentChanged() = false for a post without a content hash")
+			}
+		})
+	}
+}
+
+func TestContentHashWithoutNFT(t *testing.T) {
+	p := &Post{Title: "a", RawContent: "body", Verification: &config.Verification{}}
+	if hash := p.calculateContentHash(); hash == "" {
+		t.Errorf("calculateContentHash() is empty")
+	}
+}
@@ -0,0 +1,163 @@
+package post
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IndexFile 分区信息文件，位于分区目录下
+const IndexFile = "_index.md"
+
+// PostsSection 博客文章所在的分区
+const PostsSection = "posts"
+
+// Section 内容分区，对应 content 下除 posts 以外的一级目录
+type Section struct {
+	Name        string                 // 目录名，也是分区的页面路径
+	Title       string                 // 标题，来自 _index.md，默认为目录名
+	Description string                 // 描述，来自 _index.md
+	Content     template.HTML          // _index.md 的正文
+	Params      map[string]interface{} // _index.md 的完整 front matter
+	Pages       []*Post                // 分区中的内容，按日期排序（最新的在前）
+}
+
+// URL 分区列表页地址
+func (s *Section) URL() string {
+	return "/" + s.Name
+}
+
+// URL 页面地址：博客文章为 /posts/slug，其他分区为 /分区/slug，独立页面为 /slug
+func (p *Post) URL() string {
+	if p.Section == "" {
+		return "/" + p.Slug
+	}
+	return "/" + p.Section + "/" + p.Slug
+}
+
+// LoadSections 加载 content 目录下除 posts 以外的所有分区
+func (l *Loader) LoadSections() ([]*Section, error) {
+	entries, err := os.ReadDir(l.contentDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read content directory: %w", err)
+	}
+
+	var sections []*Section
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == PostsSection || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		section, err := l.LoadSection(filepath.Join(l.contentDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// LoadSection 加载分区目录中的 _index.md 和所有内容，草稿不会被加载，
+// 子目录中的内容同样属于该分区
+func (l *Loader) LoadSection(dir string) (*Section, error) {
+	name := filepath.Base(dir)
+	section := &Section{Name: name, Title: name}
+
+	indexPath := filepath.Join(dir, IndexFile)
+	if _, err := os.Stat(indexPath); err == nil {
+		index, err := l.Load(indexPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse section index %s: %w", indexPath, err)
+		}
+		if index.Title != "" {
+			section.Title = index.Title
+		}
+		section.Description = index.Description
+		section.Content = index.Content
+		section.Params = index.Params
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" || info.Name() == IndexFile {
+			return nil
+		}
+
+		page, err := l.loadPage(dir, path)
+		if err != nil {
+			return err
+		}
+		if page.Draft {
+			return nil
+		}
+
+		page.Section = name
+		section.Pages = append(section.Pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load section %s: %w", name, err)
+	}
+
+	sort.Slice(section.Pages, func(i, j int) bool {
+		return section.Pages[i].Date.After(section.Pages[j].Date)
+	})
+
+	return section, nil
+}
+
+// LoadPages 加载 content 目录下的 .md 文件作为独立页面，页面地址为 /slug
+func (l *Loader) LoadPages() ([]*Post, error) {
+	entries, err := os.ReadDir(l.contentDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read content directory: %w", err)
+	}
+
+	var pages []*Post
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" || entry.Name() == IndexFile {
+			continue
+		}
+
+		page, err := l.loadPage(l.contentDir, filepath.Join(l.contentDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if page.Draft {
+			continue
+		}
+
+		page.Section = ""
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// loadPage 解析分区内容或独立页面，front matter 中没有 slug 时使用相对于 dir 的文件路径
+func (l *Loader) loadPage(dir, path string) (*Post, error) {
+	page, err := l.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page %s: %w", path, err)
+	}
+
+	if _, ok := page.frontMatter["slug"]; !ok {
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path: %w", err)
+		}
+		relPath = strings.TrimSuffix(relPath, ".md")
+		page.Slug = strings.ReplaceAll(relPath, string(filepath.Separator), "/")
+	}
+	return page, nil
+}
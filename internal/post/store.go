@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Store 管理文章存储
@@ -20,25 +21,18 @@ type Store struct {
 	metas map[string]*PostMeta // slug -> meta
 
 	// 索引
-	dateIndex   []*PostMeta            // 按日期排序的文章元数据
-	seriesIndex map[string][]*PostMeta // 系列名 -> 该系列的文章元数据
-	tagsIndex   map[string][]*PostMeta // 标签 -> 带此标签的文章元数据
-
-	// 新增：筛选项统计缓存
-	seriesStats map[string]int // 系列名 -> 文章数量
-	tagsStats   map[string]int // 标签名 -> 文章数量
-	allTags     []string       // 所有标签（已排序）
+	dateIndex     []*PostMeta                        // 按日期排序的文章元数据
+	taxonomyIndex map[string]map[string][]*PostMeta  // 分类名 -> 分类项 -> 文章元数据，查询时按需建立
+	archiveIndex  map[int]map[time.Month][]*PostMeta // 年 -> 月 -> 该月的文章元数据
 }
 
 // New 创建新的文章存储
 func New() *Store {
 	return &Store{
-		posts:       make(map[string]*Post),
-		metas:       make(map[string]*PostMeta),
-		seriesIndex: make(map[string][]*PostMeta),
-		tagsIndex:   make(map[string][]*PostMeta),
-		seriesStats: make(map[string]int),
-		tagsStats:   make(map[string]int),
+		posts:         make(map[string]*Post),
+		metas:         make(map[string]*PostMeta),
+		taxonomyIndex: make(map[string]map[string][]*PostMeta),
+		archiveIndex:  make(map[int]map[time.Month][]*PostMeta),
 	}
 }
 
@@ -74,21 +68,10 @@ func (s *Store) Add(post *Post) error {
 
 	// 更新索引
 	s.updateDateIndex(post.Slug, meta)
-	s.updateSeriesIndex(post.Slug, meta)
-	s.updateTagsIndex(post.Slug, meta)
+	s.updateArchiveIndex(post.Slug, meta)
 
-	// 更新系列统计
-	if post.Series != "" {
-		s.seriesStats[post.Series]++
-	}
-
-	// 更新标签统计
-	for _, tag := range post.Tags {
-		s.tagsStats[tag]++
-	}
-
-	// 更新标签列表（如果有新标签）
-	s.updateTagsList()
+	// 分类索引在下次查询时重新建立
+	s.taxonomyIndex = make(map[string]map[string][]*PostMeta)
 
 	return nil
 }
@@ -124,7 +107,7 @@ func (s *Store) List() []*Post {
 	return posts
 }
 
-// LoadFromFS 从文件系统加载文章
+// LoadFromFS 从文件系统加载文章，所有文章加载完成后需要调用一次 ResolveLinks 解析 wiki 链接
 func (s *Store) LoadFromFS(fsys fs.FS, dir string) error {
 	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
@@ -195,11 +178,11 @@ func (s *Store) ListPaged(page, pageSize int) ([]*Post, int) {
 
 // ListByTag 获取标签下的分页文章列表
 func (s *Store) ListByTag(tag string, page, pageSize int) ([]*Post, int) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	// 获取标签下的所有文章元数据
-	tagMetas := s.tagsIndex[tag]
+	tagMetas := s.termIndex("tags")[tag]
 	total := len(tagMetas)
 
 	start := (page - 1) * pageSize
@@ -226,9 +209,7 @@ func (s *Store) ListByTag(tag string, page, pageSize int) ([]*Post, int) {
 
 // 获取系列文章
 func (s *Store) GetSeriesPosts(series string) []*PostMeta {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.seriesIndex[series]
+	return s.GetTermPosts("series", series)
 }
 
 // 更新日期索引
@@ -256,89 +237,57 @@ func (s *Store) updateDateIndex(slug string, meta *PostMeta) {
 	}
 }
 
-// 更新系列索引
-func (s *Store) updateSeriesIndex(slug string, meta *PostMeta) {
-	if meta.Series == "" {
-		return
+// 更新归档索引
+func (s *Store) updateArchiveIndex(slug string, meta *PostMeta) {
+	// 先从归档索引中移除这篇文章
+	for year, months := range s.archiveIndex {
+		for month, posts := range months {
+			for i, p := range posts {
+				if p.Slug == slug {
+					months[month] = append(posts[:i], posts[i+1:]...)
+					break
+				}
+			}
+			if len(months[month]) == 0 {
+				delete(months, month)
+			}
+		}
+		if len(months) == 0 {
+			delete(s.archiveIndex, year)
+		}
 	}
 
-	// 获取当前系列的文章列表
-	posts := s.seriesIndex[meta.Series]
-
-	// 移除旧的索引（如果存在）
-	for i, p := range posts {
-		if p.Slug == slug {
-			posts = append(posts[:i], posts[i+1:]...)
-			break
-		}
+	// 没有日期的文章不参与归档
+	if meta.Date.IsZero() {
+		return
 	}
 
-	// 添加新的索引
-	posts = append(posts, meta)
+	year, month := meta.Date.Year(), meta.Date.Month()
+	if s.archiveIndex[year] == nil {
+		s.archiveIndex[year] = make(map[time.Month][]*PostMeta)
+	}
+	posts := append(s.archiveIndex[year][month], meta)
 
-	// 按 SeriesOrder 排序
+	// 按日期排序（最新的在前）
 	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].SeriesOrder < posts[j].SeriesOrder
+		return posts[i].Date.After(posts[j].Date)
 	})
-
-	s.seriesIndex[meta.Series] = posts
-}
-
-// 更新标签索引
-func (s *Store) updateTagsIndex(slug string, meta *PostMeta) {
-	// 先从所有标签索引中移除这篇文章
-	for tag, posts := range s.tagsIndex {
-		for i, p := range posts {
-			if p.Slug == slug {
-				s.tagsIndex[tag] = append(posts[:i], posts[i+1:]...)
-				break
-			}
-		}
-	}
-
-	// 添加到新的标签索引
-	for _, tag := range meta.Tags {
-		if s.tagsIndex[tag] == nil {
-			s.tagsIndex[tag] = make([]*PostMeta, 0)
-		}
-		s.tagsIndex[tag] = append(s.tagsIndex[tag], meta)
-
-		// 按日期排序（最新的在前）
-		sort.Slice(s.tagsIndex[tag], func(i, j int) bool {
-			return s.tagsIndex[tag][i].Date.After(s.tagsIndex[tag][j].Date)
-		})
-	}
+	s.archiveIndex[year][month] = posts
 }
 
 // 获取标签下的文章
 func (s *Store) GetTagPosts(tag string) []*PostMeta {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.tagsIndex[tag]
+	return s.GetTermPosts("tags", tag)
 }
 
 // 获取所有标签及其文章数量
 func (s *Store) GetTagsCount() map[string]int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	counts := make(map[string]int)
-	for tag, posts := range s.tagsIndex {
-		counts[tag] = len(posts)
-	}
-	return counts
+	return s.GetTermsStats("tags")
 }
 
 // 获取所有系列及其文章数量
 func (s *Store) GetSeriesCount() map[string]int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	counts := make(map[string]int)
-	for series, posts := range s.seriesIndex {
-		counts[series] = len(posts)
-	}
-	return counts
+	return s.GetTermsStats("series")
 }
 
 // ListBySeries 获取系列下的分页文章列表
@@ -372,49 +321,19 @@ func (s *Store) ListBySeries(series string, page, pageSize int) ([]*Post, int) {
 	return seriesPosts[start:end], total
 }
 
-// 更新标签列表
-func (s *Store) updateTagsList() {
-	tags := make([]string, 0, len(s.tagsStats))
-	for tag := range s.tagsStats {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
-	s.allTags = tags
-}
-
 // 获取系列统计
 func (s *Store) GetSeriesStats() map[string]int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	// 返回一个副本以防止并发修改
-	stats := make(map[string]int, len(s.seriesStats))
-	for k, v := range s.seriesStats {
-		stats[k] = v
-	}
-	return stats
+	return s.GetTermsStats("series")
 }
 
 // 获取标签统计
 func (s *Store) GetTagsStats() map[string]int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	stats := make(map[string]int, len(s.tagsStats))
-	for k, v := range s.tagsStats {
-		stats[k] = v
-	}
-	return stats
+	return s.GetTermsStats("tags")
 }
 
 // 获取所有标签（已排序）
 func (s *Store) GetAllTags() []string {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	tags := make([]string, len(s.allTags))
-	copy(tags, s.allTags)
-	return tags
+	return s.GetAllTerms("tags")
 }
 
 // ListByTagAndSeries 同时按标签和系列筛选文章
@@ -456,36 +375,10 @@ func (s *Store) ListByTagAndSeries(tag, series string, page, pageSize int) ([]*P
 
 // GetPostsByTag 获取指定标签的所有文章
 func (s *Store) GetPostsByTag(tag string) []*Post {
-	var posts []*Post
-	for _, p := range s.posts {
-		for _, t := range p.Tags {
-			if t == tag {
-				posts = append(posts, p)
-				break
-			}
-		}
-	}
-	return posts
+	return s.GetPostsByTerm("tags", tag)
 }
 
 // GetPostsBySeries 获取指定系列的所有文章
 func (s *Store) GetPostsBySeries(series string) []*Post {
-	var posts []*Post
-	// 标准化输入的系列名
-	normalizedSeries := strings.ReplaceAll(series, "-", " ")
-	
-	for _, p := range s.posts {
-		// 标准化文章的系列名
-		normalizedPostSeries := strings.ReplaceAll(p.Series, "-", " ")
-		if normalizedPostSeries == normalizedSeries {
-			posts = append(posts, p)
-		}
-	}
-	
-	// 按 SeriesOrder 排序
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].SeriesOrder < posts[j].SeriesOrder
-	})
-	
-	return posts
+	return s.GetPostsByTerm("series", series)
 }
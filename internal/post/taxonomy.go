@@ -0,0 +1,134 @@
+package post
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Terms 获取文章在指定分类下的分类项
+//
+// tags、series 和 authors 读取对应字段，其他分类读取 front matter 中的同名字段，支持字符串或字符串列表
+func (p *Post) Terms(taxonomy string) []string {
+	switch taxonomy {
+	case "tags":
+		return p.Tags
+	case "series":
+		if p.Series == "" {
+			return nil
+		}
+		return []string{p.Series}
+	case "authors":
+		return p.Authors
+	}
+
+	switch v := p.Params[taxonomy].(type) {
+	case string:
+		if v = strings.TrimSpace(v); v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		terms := make([]string, 0, len(v))
+		for _, item := range v {
+			if item == nil {
+				continue
+			}
+			if term := strings.TrimSpace(fmt.Sprint(item)); term != "" {
+				terms = append(terms, term)
+			}
+		}
+		return terms
+	}
+	return nil
+}
+
+// termIndex 获取分类索引，不存在时根据所有文章建立，调用方需持有写锁
+func (s *Store) termIndex(taxonomy string) map[string][]*PostMeta {
+	if index, ok := s.taxonomyIndex[taxonomy]; ok {
+		return index
+	}
+
+	index := make(map[string][]*PostMeta)
+	for slug, post := range s.posts {
+		seen := make(map[string]bool)
+		for _, term := range post.Terms(taxonomy) {
+			if seen[term] {
+				continue
+			}
+			seen[term] = true
+			index[term] = append(index[term], s.metas[slug])
+		}
+	}
+
+	for _, metas := range index {
+		if taxonomy == "series" {
+			// 系列按 SeriesOrder 排序
+			sort.Slice(metas, func(i, j int) bool {
+				return metas[i].SeriesOrder < metas[j].SeriesOrder
+			})
+		} else {
+			// 其他分类按日期排序（最新的在前）
+			sort.Slice(metas, func(i, j int) bool {
+				return metas[i].Date.After(metas[j].Date)
+			})
+		}
+	}
+
+	s.taxonomyIndex[taxonomy] = index
+	return index
+}
+
+// GetTermPosts 获取分类项下的文章元数据
+func (s *Store) GetTermPosts(taxonomy, term string) []*PostMeta {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.termIndex(taxonomy)[term]
+}
+
+// GetTermsStats 获取分类下每个分类项的文章数量
+func (s *Store) GetTermsStats(taxonomy string) map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.termIndex(taxonomy)
+	stats := make(map[string]int, len(index))
+	for term, metas := range index {
+		stats[term] = len(metas)
+	}
+	return stats
+}
+
+// GetAllTerms 获取分类下的所有分类项（已排序）
+func (s *Store) GetAllTerms(taxonomy string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.termIndex(taxonomy)
+	terms := make([]string, 0, len(index))
+	for term := range index {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+// GetPostsByTerm 获取分类项下的所有文章，分类项中的连字符与空格视为相同
+func (s *Store) GetPostsByTerm(taxonomy, term string) []*Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	normalized := strings.ReplaceAll(term, "-", " ")
+
+	var posts []*Post
+	for t, metas := range s.termIndex(taxonomy) {
+		if strings.ReplaceAll(t, "-", " ") != normalized {
+			continue
+		}
+		for _, meta := range metas {
+			if post, ok := s.posts[meta.Slug]; ok {
+				posts = append(posts, post)
+			}
+		}
+	}
+	return posts
+}
@@ -0,0 +1,227 @@
+package resume
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+// Apply 将 JSON Resume 中的内容写入个人资料，简历中没有或为空的字段保持不变。
+// 列表整体替换，但与原有条目同名时保留其中没有对应字段的内容，如项目图片、技能百分比
+func (r *Resume) Apply(p *config.Profile) {
+	b := r.Basics
+	setString(&p.Name, b.Name)
+	setString(&p.Title, b.Label)
+	setString(&p.Avatar, b.Image)
+	setString(&p.Bio, b.Summary)
+	setString(&p.Contact.Email, b.Email)
+	setString(&p.Contact.Phone, b.Phone)
+	if b.Location != nil {
+		setString(&p.Location, b.Location.String())
+	}
+
+	r.applyProfiles(p)
+
+	if len(r.Work) > 0 {
+		experience := make([]config.Experience, 0, len(r.Work))
+		for _, work := range r.Work {
+			exp := config.Experience{}
+			for _, old := range p.Experience {
+				if old.Company == work.Name && old.Position == work.Position {
+					exp = old
+					break
+				}
+			}
+			setString(&exp.Company, work.Name)
+			setString(&exp.Position, work.Position)
+			setString(&exp.Period, joinPeriod(work.StartDate, work.EndDate))
+			setList(&exp.Responsibilities, work.Highlights)
+			experience = append(experience, exp)
+		}
+		p.Experience = experience
+	}
+
+	if len(r.Education) > 0 {
+		education := make([]config.Education, 0, len(r.Education))
+		for _, e := range r.Education {
+			edu := config.Education{}
+			for _, old := range p.Education {
+				if old.School == e.Institution {
+					edu = old
+					break
+				}
+			}
+			setString(&edu.School, e.Institution)
+			setString(&edu.Degree, e.StudyType)
+			setString(&edu.Major, e.Area)
+			setString(&edu.Year, joinPeriod(e.StartDate, e.EndDate))
+			setList(&edu.Achievements, r.awardsFrom(e.Institution))
+			education = append(education, edu)
+		}
+		p.Education = education
+	}
+
+	if len(r.Certificates) > 0 {
+		certifications := make([]config.Certification, 0, len(r.Certificates))
+		for _, c := range r.Certificates {
+			cert := config.Certification{}
+			for _, old := range p.Certifications {
+				if old.Name == c.Name {
+					cert = old
+					break
+				}
+			}
+			setString(&cert.Name, c.Name)
+			setString(&cert.Issuer, c.Issuer)
+			setString(&cert.Date, c.Date)
+			certifications = append(certifications, cert)
+		}
+		p.Certifications = certifications
+	}
+
+	if len(r.Skills) > 0 {
+		skills := make([]config.Skill, 0, len(r.Skills))
+		for _, s := range r.Skills {
+			skill := config.Skill{}
+			for _, old := range p.Skills {
+				if old.Name == s.Name {
+					skill = old
+					break
+				}
+			}
+			setString(&skill.Name, s.Name)
+			setString(&skill.Level, s.Level)
+			skills = append(skills, skill)
+		}
+		p.Skills = skills
+	}
+
+	r.applyProjects(p)
+}
+
+// applyProfiles 联系方式中已有的社交网络写入对应字段，其他写入社交链接
+func (r *Resume) applyProfiles(p *config.Profile) {
+	var links []config.SocialLink
+	for _, profile := range r.Basics.Profiles {
+		if n, ok := findNetwork(profile.Network); ok {
+			username := profile.Username
+			if username == "" && profile.URL != "" {
+				username = path.Base(strings.TrimSuffix(profile.URL, "/"))
+			}
+			setString(n.field(p), username)
+			continue
+		}
+
+		link := config.SocialLink{}
+		for _, old := range p.SocialLinks {
+			if strings.EqualFold(old.Name, profile.Network) {
+				link = old
+				break
+			}
+		}
+		setString(&link.Name, profile.Network)
+		setString(&link.URL, profile.URL)
+		links = append(links, link)
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	// 保留与联系方式重复的社交链接，导出时它们已经合并到联系方式中
+	for _, old := range p.SocialLinks {
+		if _, ok := findNetwork(old.Name); ok {
+			links = append(links, old)
+		}
+	}
+	p.SocialLinks = links
+}
+
+// applyProjects type 为 contribution 的项目写入开源贡献，其他写入项目展示
+func (r *Resume) applyProjects(p *config.Profile) {
+	var projects []config.Project
+	var contributions []config.Contribution
+	for _, rp := range r.Projects {
+		if rp.Type == ContributionType {
+			contribution := config.Contribution{}
+			for _, old := range p.Contributions {
+				if old.Name == rp.Name {
+					contribution = old
+					break
+				}
+			}
+			setString(&contribution.Name, rp.Name)
+			setString(&contribution.Description, rp.Description)
+			setString(&contribution.URL, rp.URL)
+			setList(&contribution.Contributions, rp.Highlights)
+			setString(&contribution.Impact, rp.Impact)
+			contributions = append(contributions, contribution)
+			continue
+		}
+
+		project := config.Project{}
+		for _, old := range p.Projects {
+			if old.Name == rp.Name {
+				project = old
+				break
+			}
+		}
+		setString(&project.Name, rp.Name)
+		setString(&project.Description, rp.Description)
+		setList(&project.Highlights, rp.Highlights)
+		setList(&project.Tags, rp.Keywords)
+		// 导出时优先使用项目网址，GitHub 仓库地址写入 url
+		if project.Website != "" || (rp.URL != "" && !strings.Contains(rp.URL, "github.com")) {
+			setString(&project.Website, rp.URL)
+		} else {
+			setString(&project.URL, rp.URL)
+		}
+		projects = append(projects, project)
+	}
+
+	if len(projects) > 0 {
+		p.Projects = projects
+	}
+	if len(contributions) > 0 {
+		p.Contributions = contributions
+	}
+}
+
+// awardsFrom 颁发者为指定学校的奖项，导入为该教育背景的成就
+func (r *Resume) awardsFrom(school string) []string {
+	var achievements []string
+	for _, award := range r.Awards {
+		if award.Awarder == school {
+			achievements = append(achievements, award.Title)
+		}
+	}
+	return achievements
+}
+
+// String 所在地的显示文本，优先使用城市、地区和国家
+func (l *Location) String() string {
+	var parts []string
+	for _, part := range []string{l.City, l.Region, l.CountryCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return l.Address
+	}
+	return strings.Join(parts, ", ")
+}
+
+// setList 列表不为空时覆盖
+func setList(dst *[]string, value []string) {
+	if len(value) > 0 {
+		*dst = value
+	}
+}
+
+// setString 值不为空时覆盖
+func setString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
@@ -0,0 +1,124 @@
+package resume
+
+import (
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+// FromConfig 将站点作者的个人资料转换为 JSON Resume，以 / 开头的头像地址会加上站点地址
+func FromConfig(cfg *config.Config) *Resume {
+	p := &cfg.Author
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	r := &Resume{
+		Schema: SchemaURL,
+		Basics: Basics{
+			Name:    p.Name,
+			Label:   p.Title,
+			Image:   absURL(baseURL, p.Avatar),
+			Email:   p.Contact.Email,
+			Phone:   p.Contact.Phone,
+			Summary: p.Bio,
+		},
+	}
+	if strings.HasPrefix(baseURL, "http") {
+		r.Basics.URL = baseURL + "/"
+	}
+	if p.Location != "" {
+		r.Basics.Location = &Location{City: p.Location}
+	}
+
+	// 社交账号：先导出联系方式中的账号，再导出其他社交链接
+	exported := make(map[string]bool)
+	for _, n := range networks {
+		username := *n.field(p)
+		if username == "" {
+			continue
+		}
+		profile := Profile{Network: n.name, Username: username}
+		if n.urlBase != "" {
+			profile.URL = n.urlBase + username
+		}
+		r.Basics.Profiles = append(r.Basics.Profiles, profile)
+		exported[strings.ToLower(n.name)] = true
+	}
+	for _, link := range p.SocialLinks {
+		if exported[strings.ToLower(link.Name)] {
+			continue
+		}
+		r.Basics.Profiles = append(r.Basics.Profiles, Profile{Network: link.Name, URL: link.URL})
+	}
+
+	for _, exp := range p.Experience {
+		start, end := splitPeriod(exp.Period)
+		r.Work = append(r.Work, Work{
+			Name:       exp.Company,
+			Position:   exp.Position,
+			StartDate:  start,
+			EndDate:    end,
+			Highlights: exp.Responsibilities,
+		})
+	}
+
+	for _, edu := range p.Education {
+		start, end := splitPeriod(edu.Year)
+		r.Education = append(r.Education, Education{
+			Institution: edu.School,
+			Area:        edu.Major,
+			StudyType:   edu.Degree,
+			StartDate:   start,
+			EndDate:     end,
+		})
+		for _, achievement := range edu.Achievements {
+			r.Awards = append(r.Awards, Award{Title: achievement, Awarder: edu.School, Date: end})
+		}
+	}
+
+	for _, cert := range p.Certifications {
+		r.Certificates = append(r.Certificates, Certificate{
+			Name:   cert.Name,
+			Issuer: cert.Issuer,
+			Date:   isoDate(cert.Date),
+		})
+	}
+
+	for _, skill := range p.Skills {
+		r.Skills = append(r.Skills, Skill{Name: skill.Name, Level: skill.Level})
+	}
+
+	for _, project := range p.Projects {
+		url := project.Website
+		if url == "" {
+			url = project.URL
+		}
+		r.Projects = append(r.Projects, Project{
+			Name:        project.Name,
+			Description: project.Description,
+			Highlights:  project.Highlights,
+			Keywords:    project.Tags,
+			URL:         url,
+		})
+	}
+
+	for _, contribution := range p.Contributions {
+		r.Projects = append(r.Projects, Project{
+			Name:        contribution.Name,
+			Description: contribution.Description,
+			Highlights:  contribution.Contributions,
+			URL:         contribution.URL,
+			Type:        ContributionType,
+			Impact:      contribution.Impact,
+		})
+	}
+
+	return r
+}
+
+// absURL 为站内地址加上站点地址，站点地址不是完整 URL 时原样返回
+func absURL(baseURL, path string) string {
+	if !strings.HasPrefix(path, "/") || !strings.HasPrefix(baseURL, "http") {
+		return path
+	}
+	return baseURL + path
+}
@@ -0,0 +1,209 @@
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+// SchemaURL JSON Resume 标准的 schema 地址
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Resume JSON Resume 格式的简历，只包含与个人资料有对应关系的部分
+type Resume struct {
+	Schema       string        `json:"$schema,omitempty"`
+	Basics       Basics        `json:"basics"`
+	Work         []Work        `json:"work,omitempty"`
+	Education    []Education   `json:"education,omitempty"`
+	Awards       []Award       `json:"awards,omitempty"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+	Skills       []Skill       `json:"skills,omitempty"`
+	Projects     []Project     `json:"projects,omitempty"`
+}
+
+// Basics 基本信息
+type Basics struct {
+	Name     string    `json:"name,omitempty"`
+	Label    string    `json:"label,omitempty"`
+	Image    string    `json:"image,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+// Location 所在地
+type Location struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// Profile 社交账号
+type Profile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// Work 工作经历
+type Work struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// Education 教育背景
+type Education struct {
+	Institution string `json:"institution"`
+	URL         string `json:"url,omitempty"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+	Score       string `json:"score,omitempty"`
+}
+
+// Award 奖项，教育背景中的成就导出为颁发者为学校的奖项
+type Award struct {
+	Title   string `json:"title"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// Certificate 证书
+type Certificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Skill 技能
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Project 项目，开源贡献导出为 type 为 contribution 的项目
+type Project struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Impact      string   `json:"impact,omitempty"` // 扩展字段，对应开源贡献的影响
+}
+
+// ContributionType 开源贡献在 JSON Resume 中的项目类型
+const ContributionType = "contribution"
+
+// Load 读取 JSON Resume 文件
+func Load(path string) (*Resume, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume: %w", err)
+	}
+
+	var r Resume
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse resume: %w", err)
+	}
+	return &r, nil
+}
+
+// JSON 输出格式化的 JSON
+func (r *Resume) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resume: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// network 个人资料中以账号形式保存的社交网络
+type network struct {
+	name    string
+	urlBase string // 个人主页地址前缀，为空时不生成 url
+	field   func(p *config.Profile) *string
+}
+
+var networks = []network{
+	{"GitHub", "https://github.com/", func(p *config.Profile) *string { return &p.GitHub }},
+	{"Twitter", "https://x.com/", func(p *config.Profile) *string { return &p.Contact.Twitter }},
+	{"Telegram", "https://t.me/", func(p *config.Profile) *string { return &p.Contact.Telegram }},
+	{"WeChat", "", func(p *config.Profile) *string { return &p.Contact.WeChat }},
+	{"Bilibili", "", func(p *config.Profile) *string { return &p.Contact.Bilibili }},
+	{"Weibo", "", func(p *config.Profile) *string { return &p.Contact.Weibo }},
+	{"Douyin", "", func(p *config.Profile) *string { return &p.Contact.Douyin }},
+	{"Xiaohongshu", "", func(p *config.Profile) *string { return &p.Contact.Xiaohongshu }},
+	{"Juejin", "", func(p *config.Profile) *string { return &p.Contact.Juejin }},
+	{"Jike", "", func(p *config.Profile) *string { return &p.Contact.Jike }},
+}
+
+// findNetwork 按名称查找社交网络，忽略大小写，X 视为 Twitter
+func findNetwork(name string) (network, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "x" {
+		name = "twitter"
+	}
+	for _, n := range networks {
+		if strings.ToLower(n.name) == name {
+			return n, true
+		}
+	}
+	return network{}, false
+}
+
+// splitPeriod 将 "2021.07 - 至今" 形式的时间段拆分为 ISO 8601 格式的开始和结束日期，
+// 只有一个日期时视为结束日期，"至今" 等表示当前的结束日期为空
+func splitPeriod(period string) (start, end string) {
+	period = periodSeparators.Replace(period)
+	parts := strings.Split(period, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	if len(parts) == 1 {
+		return "", isoDate(parts[0])
+	}
+	return isoDate(parts[0]), isoDate(parts[1])
+}
+
+// periodSeparators 时间段中开始和结束日期之间的分隔符
+var periodSeparators = strings.NewReplacer(" - ", "|", "~", "|", "–", "|", "—", "|", " 至 ", "|")
+
+// joinPeriod 将开始和结束日期合并为时间段，结束日期为空时表示至今
+func joinPeriod(start, end string) string {
+	switch {
+	case start == "":
+		return end
+	case end == "":
+		return start + " - 至今"
+	default:
+		return start + " - " + end
+	}
+}
+
+// isoDate 将 2021.07、2021/07 转换为 2021-07，"至今"、"present" 等返回空字符串
+func isoDate(date string) string {
+	switch strings.ToLower(date) {
+	case "", "至今", "今", "现在", "present", "now", "current":
+		return ""
+	}
+	return strings.NewReplacer(".", "-", "/", "-").Replace(date)
+}
@@ -0,0 +1,183 @@
+package seo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/post"
+)
+
+// crumb 面包屑导航中的一项
+type crumb struct {
+	Name string
+	URL  string
+}
+
+// person 站点作者的 schema.org Person，没有设置作者名称时返回 nil
+func person(site *config.Config) map[string]interface{} {
+	profile := site.Author
+	if profile.Name == "" {
+		return nil
+	}
+
+	p := map[string]interface{}{
+		"@type": "Person",
+		"name":  profile.Name,
+		"url":   config.AbsURL(site.BaseURL, "/"),
+	}
+	if profile.Title != "" {
+		p["jobTitle"] = profile.Title
+	}
+	if profile.Bio != "" {
+		p["description"] = profile.Bio
+	}
+	if profile.Avatar != "" {
+		p["image"] = config.AbsURL(site.BaseURL, sitePath(profile.Avatar))
+	}
+
+	var sameAs []string
+	if profile.GitHub != "" {
+		sameAs = append(sameAs, profileURL("https://github.com/", profile.GitHub))
+	}
+	if profile.Contact.Twitter != "" {
+		sameAs = append(sameAs, profileURL("https://twitter.com/", profile.Contact.Twitter))
+	}
+	for _, link := range profile.SocialLinks {
+		if strings.HasPrefix(link.URL, "http") {
+			sameAs = append(sameAs, link.URL)
+		}
+	}
+	if len(sameAs) > 0 {
+		p["sameAs"] = uniq(sameAs)
+	}
+	return p
+}
+
+// author 文章作者的 schema.org Person
+func author(a *config.Author, site *config.Config) map[string]interface{} {
+	p := map[string]interface{}{
+		"@type": "Person",
+		"name":  a.Name,
+		"url":   config.AbsURL(site.BaseURL, a.URL()),
+	}
+	if a.Avatar != "" {
+		p["image"] = config.AbsURL(site.BaseURL, sitePath(a.Avatar))
+	}
+
+	var sameAs []string
+	if a.Website != "" {
+		sameAs = append(sameAs, a.Website)
+	}
+	if a.GitHub != "" {
+		sameAs = append(sameAs, profileURL("https://github.com/", a.GitHub))
+	}
+	if a.Twitter != "" {
+		sameAs = append(sameAs, profileURL("https://twitter.com/", a.Twitter))
+	}
+	if len(sameAs) > 0 {
+		p["sameAs"] = sameAs
+	}
+	return p
+}
+
+// articleSchema 博客文章为 BlogPosting，其他页面为 Article
+func articleSchema(m *Meta, p *post.Post, site *config.Config, blog bool) map[string]interface{} {
+	kind := "Article"
+	if blog {
+		kind = "BlogPosting"
+	}
+
+	url := m.Canonical
+	if url == "" {
+		url = config.AbsURL(site.BaseURL, p.URL())
+	}
+	schema := map[string]interface{}{
+		"@context":         "https://schema.org",
+		"@type":            kind,
+		"headline":         p.Title,
+		"url":              url,
+		"mainEntityOfPage": url,
+		"inLanguage":       site.LanguageCode(),
+	}
+	if p.Description != "" {
+		schema["description"] = p.Description
+	}
+	if !p.Date.IsZero() {
+		schema["datePublished"] = p.Date.Format(time.RFC3339)
+	}
+	if !m.Modified.IsZero() {
+		schema["dateModified"] = m.Modified.Format(time.RFC3339)
+	}
+	if len(p.Tags) > 0 {
+		schema["keywords"] = strings.Join(p.Tags, ", ")
+	}
+	if p.Series != "" {
+		schema["isPartOf"] = map[string]interface{}{
+			"@type": "CreativeWorkSeries",
+			"name":  p.Series,
+		}
+	}
+	if m.Image != "" {
+		schema["image"] = m.Image
+	}
+	if p.ReadingTime > 0 {
+		schema["timeRequired"] = "PT" + strconv.Itoa(p.ReadingTime) + "M"
+	}
+
+	// 文章指定了作者时使用文章作者，否则使用站点作者
+	var authors []interface{}
+	for _, a := range p.GetAuthors() {
+		authors = append(authors, author(a, site))
+	}
+	home := person(site)
+	if len(authors) == 0 && home != nil {
+		authors = append(authors, home)
+	}
+	if len(authors) > 0 {
+		schema["author"] = authors
+	}
+	if home != nil {
+		schema["publisher"] = home
+	}
+	return schema
+}
+
+// breadcrumbSchema 面包屑导航的 BreadcrumbList
+func breadcrumbSchema(site *config.Config, crumbs []crumb) map[string]interface{} {
+	items := make([]interface{}, 0, len(crumbs))
+	for i, c := range crumbs {
+		items = append(items, map[string]interface{}{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"name":     c.Name,
+			"item":     config.AbsURL(site.BaseURL, c.URL),
+		})
+	}
+	return map[string]interface{}{
+		"@context":        "https://schema.org",
+		"@type":           "BreadcrumbList",
+		"itemListElement": items,
+	}
+}
+
+// profileURL 用户名转换为个人主页地址，已是完整地址时原样返回
+func profileURL(prefix, name string) string {
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return name
+	}
+	return prefix + strings.TrimPrefix(name, "@")
+}
+
+func uniq(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	result := items[:0]
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
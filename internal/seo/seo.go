@@ -0,0 +1,298 @@
+// Package seo 根据页面数据生成 head 中的 SEO 标签：描述、规范地址、robots 指令、
+// Open Graph 和 Twitter 卡片，以及 schema.org 的 JSON-LD 结构化数据
+package seo
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/post"
+	"github.com/jiangjiax/stars/internal/template/funcs"
+)
+
+// seo 需要文章和站点信息，funcs 不能依赖 post，因此在这里注册到 DefaultFuncs。
+// 导入 seo 的包（如 template 和 generator）解析模板之前函数就已可用
+func init() {
+	funcs.AddFunc("seo", Render)
+}
+
+// Meta 一个页面的 SEO 信息
+type Meta struct {
+	Title       string
+	Description string
+	Keywords    []string
+	Author      string
+	Canonical   string // 完整地址，无法确定页面地址时为空
+	Robots      string
+	Type        string // og:type，文章和页面为 article，其他为 website
+	Image       string // 分享图片的完整地址
+	ImageWidth  int
+	ImageHeight int
+	SiteName    string
+	Published   time.Time
+	Modified    time.Time // 最后修改时间，与发布时间相同时为零
+	Tags        []string
+	TwitterSite string // 站点的 Twitter 账号，如 @alice
+	TwitterUser string // 文章作者的 Twitter 账号
+	Schemas     []interface{}
+}
+
+// pager 分页信息，即 template.Pagination
+type pager interface {
+	URL() string
+}
+
+// Render 模板函数 seo，参数为页面模板数据：{{ seo . }}
+func Render(data map[string]interface{}) template.HTML {
+	meta := FromData(data)
+	if meta == nil {
+		return ""
+	}
+
+	html, err := meta.HTML()
+	if err != nil {
+		log.Printf("Warning: failed to render SEO tags: %v", err)
+		return ""
+	}
+	return html
+}
+
+// FromData 从页面模板数据生成 SEO 信息，数据中没有站点配置时返回 nil
+func FromData(data map[string]interface{}) *Meta {
+	site, ok := data["Site"].(*config.Config)
+	if !ok || site == nil {
+		return nil
+	}
+
+	m := &Meta{
+		Title:       site.Title,
+		Description: site.Description,
+		Keywords:    site.SEO.Keywords,
+		Author:      site.Author.Name,
+		Robots:      site.SEO.Robots,
+		Type:        "website",
+		SiteName:    site.Title,
+		TwitterSite: twitterHandle(site.Author.Contact.Twitter),
+	}
+	if m.Description == "" && site.Author.Bio != "" {
+		m.Description = site.Author.Name + ":" + site.Author.Bio
+	}
+	if title, ok := data["Title"].(string); ok && title != "" {
+		m.Title = title
+	}
+	if site.SEO.Image != "" {
+		m.Image = config.AbsURL(site.BaseURL, sitePath(site.SEO.Image))
+	}
+
+	kind, _ := data["Kind"].(string)
+	path := ""
+	switch kind {
+	case "index":
+		path = "/"
+	case "tags":
+		path = "/tags"
+	}
+	if p, ok := data["Pagination"].(pager); ok && p != nil {
+		path = p.URL()
+	}
+
+	home := person(site)
+	var article *post.Post
+	switch {
+	case kind == "single":
+		article, _ = data["Post"].(*post.Post)
+	case kind == "page":
+		article, _ = data["Page"].(*post.Post)
+	}
+
+	if article != nil {
+		path = article.URL()
+		m.fromPost(article, site)
+
+		breadcrumbs := []crumb{{Name: site.Title, URL: "/"}}
+		if article.Section != "" {
+			name := article.Section
+			if article.Section == post.PostsSection {
+				name = "文章"
+			} else if section, ok := data["Section"].(*post.Section); ok && section.Title != "" {
+				name = section.Title
+			}
+			breadcrumbs = append(breadcrumbs, crumb{Name: name, URL: "/" + article.Section})
+		}
+		breadcrumbs = append(breadcrumbs, crumb{Name: article.Title, URL: article.URL()})
+
+		m.Schemas = append(m.Schemas,
+			articleSchema(m, article, site, kind == "single"),
+			breadcrumbSchema(site, breadcrumbs),
+		)
+	} else if kind == "index" {
+		m.Schemas = append(m.Schemas, map[string]interface{}{
+			"@context":    "https://schema.org",
+			"@type":       "WebSite",
+			"name":        site.Title,
+			"description": m.Description,
+			"url":         config.AbsURL(site.BaseURL, "/"),
+			"inLanguage":  site.LanguageCode(),
+		})
+		if home != nil {
+			home["@context"] = "https://schema.org"
+			m.Schemas = append(m.Schemas, home)
+		}
+	}
+
+	if path != "" && m.Canonical == "" {
+		m.Canonical = config.AbsURL(site.BaseURL, path)
+	}
+	return m
+}
+
+// fromPost 使用文章的信息覆盖站点默认值
+func (m *Meta) fromPost(p *post.Post, site *config.Config) {
+	m.Title = p.Title
+	m.Type = "article"
+	m.Published = p.Date
+	if p.Lastmod.After(p.Date) {
+		m.Modified = p.Lastmod
+	}
+	m.Tags = p.Tags
+	if p.Description != "" {
+		m.Description = p.Description
+	}
+	if len(p.Tags) > 0 {
+		m.Keywords = p.Tags
+	}
+	if p.Robots != "" {
+		m.Robots = p.Robots
+	}
+	if p.Canonical != "" {
+		m.Canonical = config.AbsURL(site.BaseURL, p.Canonical)
+	}
+	if p.OGImage != "" {
+		m.Image = config.AbsURL(site.BaseURL, p.OGImage)
+		m.ImageWidth, m.ImageHeight = 1200, 630
+	}
+	if authors := p.GetAuthors(); len(authors) > 0 {
+		names := make([]string, 0, len(authors))
+		for _, a := range authors {
+			names = append(names, a.Name)
+		}
+		m.Author = strings.Join(names, ", ")
+		m.TwitterUser = twitterHandle(authors[0].Twitter)
+	}
+}
+
+// HTML 生成 head 中的标签
+func (m *Meta) HTML() (template.HTML, error) {
+	schemas := make([]template.JS, 0, len(m.Schemas))
+	for _, schema := range m.Schemas {
+		// json.Marshal 会转义 <、> 和 &，可以直接放在 script 标签中
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return "", err
+		}
+		schemas = append(schemas, template.JS(data))
+	}
+
+	var buf strings.Builder
+	if err := tagsTemplate.Execute(&buf, map[string]interface{}{
+		"Meta":    m,
+		"Schemas": schemas,
+	}); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
+var tagsTemplate = template.Must(template.New("seo").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"iso":  func(t time.Time) string { return t.Format(time.RFC3339) },
+}).Parse(`{{ with .Meta -}}
+<meta name="description" content="{{ .Description }}">
+{{- with .Keywords }}
+<meta name="keywords" content="{{ join . ", " }}">
+{{- end }}
+{{- with .Author }}
+<meta name="author" content="{{ . }}">
+{{- end }}
+{{- with .Robots }}
+<meta name="robots" content="{{ . }}">
+{{- end }}
+{{- with .Canonical }}
+<link rel="canonical" href="{{ . }}">
+{{- end }}
+<meta property="og:type" content="{{ .Type }}">
+<meta property="og:title" content="{{ .Title }}">
+<meta property="og:description" content="{{ .Description }}">
+{{- with .Canonical }}
+<meta property="og:url" content="{{ . }}">
+{{- end }}
+<meta property="og:site_name" content="{{ .SiteName }}">
+{{- if .Image }}
+<meta property="og:image" content="{{ .Image }}">
+{{- if .ImageWidth }}
+<meta property="og:image:width" content="{{ .ImageWidth }}">
+<meta property="og:image:height" content="{{ .ImageHeight }}">
+{{- end }}
+{{- end }}
+{{- if eq .Type "article" }}
+{{- if not .Published.IsZero }}
+<meta property="article:published_time" content="{{ iso .Published }}">
+{{- end }}
+{{- if not .Modified.IsZero }}
+<meta property="article:modified_time" content="{{ iso .Modified }}">
+{{- end }}
+{{- range .Tags }}
+<meta property="article:tag" content="{{ . }}">
+{{- end }}
+{{- end }}
+<meta name="twitter:card" content="{{ if .Image }}summary_large_image{{ else }}summary{{ end }}">
+<meta name="twitter:title" content="{{ .Title }}">
+<meta name="twitter:description" content="{{ .Description }}">
+{{- with .Image }}
+<meta name="twitter:image" content="{{ . }}">
+{{- end }}
+{{- with .TwitterSite }}
+<meta name="twitter:site" content="{{ . }}">
+{{- end }}
+{{- with .TwitterUser }}
+<meta name="twitter:creator" content="{{ . }}">
+{{- end }}
+{{- end }}
+{{- range .Schemas }}
+<script type="application/ld+json">{{ . }}</script>
+{{- end }}
+`))
+
+// twitterHandle 将 Twitter 用户名或主页地址转换为 @用户名
+func twitterHandle(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		s = s[i+1:]
+	}
+	s = strings.TrimPrefix(s, "@")
+	if s == "" {
+		return ""
+	}
+	return "@" + s
+}
+
+// sitePath 将模板中的相对地址（如构建时的 ../../static/a.jpg）还原为站内路径
+func sitePath(p string) string {
+	if strings.Contains(p, "://") {
+		return p
+	}
+	for {
+		switch {
+		case strings.HasPrefix(p, "../"):
+			p = p[3:]
+		case strings.HasPrefix(p, "./"):
+			p = p[2:]
+		default:
+			return "/" + strings.TrimPrefix(p, "/")
+		}
+	}
+}
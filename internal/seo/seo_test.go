@@ -0,0 +1,102 @@
+package seo
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/post"
+)
+
+var jsonLD = regexp.MustCompile(`<script type="application/ld\+json">(.*?)</script>`)
+
+func TestJSONLD(t *testing.T) {
+	site := &config.Config{
+		Title:   "Stars",
+		BaseURL: "https://example.com",
+		Author:  config.Profile{Name: "Alice"},
+	}
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	newPost := func(section string) *post.Post {
+		return &post.Post{
+			Title:       "Hello <World>",
+			Slug:        "hello",
+			Section:     section,
+			Description: "A post",
+			Tags:        []string{"go", "web"},
+			Date:        date,
+			Lastmod:     date.Add(24 * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		kind   string
+		key    string
+		post   *post.Post
+		schema map[string]interface{}
+		crumbs []string
+	}{
+		{"post", "single", "Post", newPost(post.PostsSection), map[string]interface{}{
+			"@context":         "https://schema.org",
+			"@type":            "BlogPosting",
+			"headline":         "Hello <World>",
+			"url":              "https://example.com/posts/hello",
+			"mainEntityOfPage": "https://example.com/posts/hello",
+			"description":      "A post",
+			"datePublished":    "2025-01-02T03:04:05Z",
+			"dateModified":     "2025-01-03T03:04:05Z",
+			"keywords":         "go, web",
+			"inLanguage":       "zh-CN",
+		}, []string{"https://example.com/", "https://example.com/posts", "https://example.com/posts/hello"}},
+		{"page", "page", "Page", newPost(""), map[string]interface{}{
+			"@type": "Article",
+			"url":   "https://example.com/hello",
+		}, []string{"https://example.com/", "https://example.com/hello"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := string(Render(map[string]interface{}{"Site": site, "Kind": tt.kind, tt.key: tt.post}))
+			matches := jsonLD.FindAllStringSubmatch(html, -1)
+			if len(matches) != 2 {
+				t.Fatalf("got %d JSON-LD blocks, want 2:\n%s", len(matches), html)
+			}
+
+			var article, breadcrumbs map[string]interface{}
+			if err := json.Unmarshal([]byte(matches[0][1]), &article); err != nil {
+				t.Fatalf("invalid JSON-LD: %v\n%s", err, matches[0][1])
+			}
+			if err := json.Unmarshal([]byte(matches[1][1]), &breadcrumbs); err != nil {
+				t.Fatalf("invalid JSON-LD: %v\n%s", err, matches[1][1])
+			}
+
+			for key, want := range tt.schema {
+				if got := article[key]; got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+			authors, _ := article["author"].([]interface{})
+			if len(authors) != 1 || authors[0].(map[string]interface{})["name"] != "Alice" {
+				t.Errorf("author = %v, want the site author", article["author"])
+			}
+			if publisher, _ := article["publisher"].(map[string]interface{}); publisher["name"] != "Alice" {
+				t.Errorf("publisher = %v, want the site author", article["publisher"])
+			}
+
+			if breadcrumbs["@type"] != "BreadcrumbList" {
+				t.Fatalf("second block @type = %v, want BreadcrumbList", breadcrumbs["@type"])
+			}
+			items, _ := breadcrumbs["itemListElement"].([]interface{})
+			if len(items) != len(tt.crumbs) {
+				t.Fatalf("got %d breadcrumbs, want %d", len(items), len(tt.crumbs))
+			}
+			for i, want := range tt.crumbs {
+				if got := items[i].(map[string]interface{})["item"]; got != want {
+					t.Errorf("breadcrumb %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
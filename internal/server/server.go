@@ -1,7 +1,8 @@
 package server
 
 import (
-	"encoding/xml"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,13 +12,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/jiangjiax/stars/internal/asset"
 	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/cv"
+	"github.com/jiangjiax/stars/internal/feed"
+	"github.com/jiangjiax/stars/internal/github"
+	"github.com/jiangjiax/stars/internal/imaging"
+	"github.com/jiangjiax/stars/internal/markup"
+	"github.com/jiangjiax/stars/internal/ogimage"
 	"github.com/jiangjiax/stars/internal/post"
-	"github.com/jiangjiax/stars/internal/rss"
+	"github.com/jiangjiax/stars/internal/resume"
 	"github.com/jiangjiax/stars/internal/template"
+	"github.com/jiangjiax/stars/internal/template/funcs"
 )
 
 type Server struct {
@@ -26,7 +35,10 @@ type Server struct {
 	port       int
 	engine     *template.Engine
 	posts      *post.Store
+	sections   []*post.Section // content 下除 posts 以外的分区
+	pages      []*post.Post    // content 下的独立页面
 	assets     *asset.Pipeline
+	images     *imaging.Processor // 处理后的图片，未启用图片处理时为空
 	router     chi.Router
 }
 
@@ -37,6 +49,15 @@ func New(projectDir string, cfg *config.Config, port int) (*Server, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// 同步个人资料中 GitHub 项目的数据，失败时使用配置中的值
+	if cfg.GitHub.Sync {
+		client, err := github.NewClient(cfg.GitHub, filepath.Join(projectDir, ".cache", "github"))
+		if err != nil {
+			return nil, err
+		}
+		client.SyncProjects(&cfg.Author)
+	}
+
 	// 初始化资源管理器
 	assets := asset.New(projectDir, cfg.Theme)
 
@@ -51,60 +72,19 @@ func New(projectDir string, cfg *config.Config, port int) (*Server, error) {
 		return nil, fmt.Errorf("failed to create template engine: %w", err)
 	}
 
-	// 创建文章存储
-	posts := post.New()
-
-	// 递归加载所有文章
-	postsDir := filepath.Join(projectDir, "content/posts")
-	err = filepath.Walk(postsDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// 跳过目录
-		if info.IsDir() {
-			return nil
-		}
-
-		// 只处理 .md 文件
-		if !strings.HasSuffix(info.Name(), ".md") {
-			return nil
-		}
-
-		// 解析文章
-		parsePost, err := post.ParsePost(path)
-		if err != nil {
-			return fmt.Errorf("failed to parse post %s: %w", path, err)
-		}
-
-		// 如果没有验证信息或内容有变化，更新 contentHash
-		if parsePost.Verification == nil || parsePost.ContentChanged() {
-			if err := parsePost.UpdateContentHash(); err != nil {
-				return fmt.Errorf("failed to update content hash: %w", err)
-			}
-		}
-
-		// 如果没有设置 slug，使用相对路径作为 URL
-		if parsePost.Slug == "" {
-			relPath, err := filepath.Rel(postsDir, path)
-			if err != nil {
-				return fmt.Errorf("failed to get relative path: %w", err)
-			}
-			// 移除 .md 后缀
-			relPath = strings.TrimSuffix(relPath, ".md")
-			// 将路径分隔符转换为 URL 分隔符
-			parsePost.Slug = strings.ReplaceAll(relPath, string(filepath.Separator), "/")
-		}
-
-		if err := posts.Add(parsePost); err != nil {
-			return fmt.Errorf("failed to add post %s: %w", path, err)
-		}
-
-		return nil
-	})
+	// 内容加载器，合并站点配置和各级 _index.md 中的 cascade，
+	// 模板中的 imageResize、srcset 与内容使用同一个图片处理器
+	loader := post.NewLoader(projectDir, cfg)
+	funcs.SetImageProcessor(loader.Images())
 
+	// 加载分区和独立页面
+	sections, err := loader.LoadSections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sections: %w", err)
+	}
+	pages, err := loader.LoadPages()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load posts: %w", err)
+		return nil, fmt.Errorf("failed to load pages: %w", err)
 	}
 
 	// 创建服务器实例
@@ -113,8 +93,15 @@ func New(projectDir string, cfg *config.Config, port int) (*Server, error) {
 		projectDir: projectDir,
 		port:       port,
 		engine:     engine,
-		posts:      posts,
+		posts:      post.New(),
+		sections:   sections,
+		pages:      pages,
 		assets:     assets,
+		images:     loader.Images(),
+	}
+
+	if err := srv.loadPosts(loader); err != nil {
+		return nil, err
 	}
 
 	return srv, nil
@@ -149,30 +136,81 @@ func (s *Server) Start() error {
 		http.NotFound(w, r)
 	})
 
+	// 代码高亮样式根据配置生成
+	router.Get("/static/css/chroma.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css; charset=utf-8")
+		if err := markup.WriteHighlightCSS(w, s.config.Markup.Highlight); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
 	// 注册路由
 	router.Handle("/static/*", http.StripPrefix("/static/", s.addCorrectMIMETypes(fileServer)))
 
-	// RSS feed 路由
-	router.Get("/feed.xml", func(w http.ResponseWriter, r *http.Request) {
-		// 获取所有文章并按日期排序
-		posts := s.posts.GetAll()
-		sort.Slice(posts, func(i, j int) bool {
-			return posts[i].Date.After(posts[j].Date)
+	// 处理后的图片，渲染页面时生成在缓存目录中
+	if s.images != nil {
+		router.Handle(imaging.URLPrefix+"*", http.StripPrefix(imaging.URLPrefix, http.FileServer(http.Dir(s.images.Dir()))))
+	}
+
+	// 站点的 RSS、Atom 和 JSON Feed 订阅源
+	for _, format := range feed.Formats {
+		format := format
+		router.Get("/"+format.FileName, func(w http.ResponseWriter, r *http.Request) {
+			s.serveFeed(w, r, format, s.posts.GetAll(), feed.Options{Limit: s.config.Feeds.Limit})
 		})
+	}
 
-		// 生成 RSS feed
-		feed, err := rss.GenerateFeed(posts, s.config.Title, s.config.BaseURL)
+	// 播客订阅源
+	router.Get("/"+feed.PodcastFormat.FileName, func(w http.ResponseWriter, r *http.Request) {
+		if !s.config.Podcast.Enabled {
+			http.NotFound(w, r)
+			return
+		}
+		var buf bytes.Buffer
+		if err := feed.NewPodcast(s.config, s.posts.GetAll()).Write(&buf, feed.PodcastFormat); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", feed.PodcastFormat.MIME+"; charset=utf-8")
+		w.Write(buf.Bytes())
+	})
+
+	// 文章链接图谱
+	router.Get("/graph.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(s.posts.Graph())
+	})
+
+	// JSON Resume 格式的个人资料
+	router.Get("/resume.json", func(w http.ResponseWriter, r *http.Request) {
+		if s.config.Author.Name == "" {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := resume.FromConfig(s.config).JSON()
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
 
-		// 输出 RSS
-		w.Header().Set("Content-Type", "application/xml")
-		w.Write([]byte(xml.Header))
-		encoder := xml.NewEncoder(w)
-		encoder.Indent("", "  ")
-		encoder.Encode(feed)
+	// 简历 PDF，需要在配置中启用
+	router.Get("/cv.pdf", func(w http.ResponseWriter, r *http.Request) {
+		if !s.config.CV.Enabled {
+			http.NotFound(w, r)
+			return
+		}
+		var buf bytes.Buffer
+		if err := cv.Render(s.projectDir, s.config, &buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(buf.Bytes())
 	})
 
 	// 其他路由
@@ -204,15 +242,22 @@ func (s *Server) handleContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 处理标签页面
-	if strings.HasPrefix(r.URL.Path, "/tags/") {
-		s.handleTaxonomy(w, r, "tags")
-		return
+	// 处理分类页面（标签、系列和自定义分类）
+	for _, taxonomy := range s.config.GetTaxonomies() {
+		if strings.HasPrefix(r.URL.Path, "/"+taxonomy.Name+"/") && r.URL.Path != "/"+taxonomy.Name+"/" {
+			s.handleTaxonomy(w, r, taxonomy)
+			return
+		}
+		// 标签云页面单独处理
+		if taxonomy.Name != "tags" && strings.TrimSuffix(r.URL.Path, "/") == "/"+taxonomy.Name {
+			s.handleTerms(w, r, taxonomy)
+			return
+		}
 	}
 
-	// 处理系列页面
-	if strings.HasPrefix(r.URL.Path, "/series/") {
-		s.handleTaxonomy(w, r, "series")
+	// 处理归档页面
+	if r.URL.Path == "/archives" || strings.HasPrefix(r.URL.Path, "/archives/") {
+		s.handleArchive(w, r)
 		return
 	}
 
@@ -236,6 +281,12 @@ func (s *Server) handleContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 处理文章分享图片
+	if strings.HasPrefix(r.URL.Path, "/posts/") && strings.HasSuffix(r.URL.Path, "/"+ogimage.FileName) {
+		s.handleShareImage(w, r)
+		return
+	}
+
 	// 处理单篇文章页面
 	if strings.HasPrefix(r.URL.Path, "/posts/") && !strings.Contains(r.URL.Path, "/page/") {
 		s.handlePost(w, r)
@@ -260,9 +311,136 @@ func (s *Server) handleContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 处理分区和独立页面
+	if s.handleSection(w, r) {
+		return
+	}
+
 	http.NotFound(w, r)
 }
 
+// handleSection 处理分区列表页、分区内容页和独立页面，路径不匹配时返回 false
+func (s *Server) handleSection(w http.ResponseWriter, r *http.Request) bool {
+	path := strings.Trim(r.URL.Path, "/")
+	name, rest, _ := strings.Cut(path, "/")
+
+	var (
+		html string
+		err  error
+	)
+	switch section, page := s.findSection(name), s.findPage(path); {
+	case section != nil && (rest == "" || strings.HasPrefix(rest, "page/")):
+		// 分区列表页及分页
+		current := 1
+		if p, err := strconv.Atoi(strings.TrimPrefix(rest, "page/")); err == nil && p > 0 {
+			current = p
+		}
+
+		pageSize := 10
+		total := len(section.Pages)
+		start := (current - 1) * pageSize
+		if start > total {
+			start = total
+		}
+		end := start + pageSize
+		if end > total {
+			end = total
+		}
+
+		html, err = s.engine.RenderSection(section.Name, map[string]interface{}{
+			"Title":      section.Title + " - " + s.config.Title,
+			"Section":    section,
+			"Pages":      section.Pages[start:end],
+			"Pagination": template.NewPagination(current, pageSize, total, section.URL()),
+			"TotalPosts": total,
+			"Site":       s.config,
+		})
+	case section != nil:
+		// 分区内容页
+		var found *post.Post
+		for _, p := range section.Pages {
+			if p.Slug == rest {
+				found = p
+				break
+			}
+		}
+		if found == nil {
+			return false
+		}
+		html, err = s.engine.RenderPage(section.Name, map[string]interface{}{
+			"Title":   found.Title + " - " + s.config.Title,
+			"Page":    found,
+			"Section": section,
+			"Site":    s.config,
+		})
+	case page != nil:
+		// 独立页面
+		html, err = s.engine.RenderPage("", map[string]interface{}{
+			"Title": page.Title + " - " + s.config.Title,
+			"Page":  page,
+			"Site":  s.config,
+		})
+	default:
+		return false
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, html)
+	return true
+}
+
+// findSection 根据名称查找分区
+func (s *Server) findSection(name string) *post.Section {
+	for _, section := range s.sections {
+		if section.Name == name {
+			return section
+		}
+	}
+	return nil
+}
+
+// findPage 根据 slug 查找独立页面
+func (s *Server) findPage(slug string) *post.Post {
+	for _, page := range s.pages {
+		if page.Slug == slug {
+			return page
+		}
+	}
+	return nil
+}
+
+// handleShareImage 生成文章的分享图片，需要在配置中启用
+func (s *Server) handleShareImage(w http.ResponseWriter, r *http.Request) {
+	if !s.config.OGImage.Enabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	slug := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/posts/"), "/"+ogimage.FileName)
+	p, err := s.posts.GetBySlug(slug)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	renderer, err := ogimage.New(s.projectDir, s.config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := renderer.Render(ogimage.FromPost(p, s.config))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(data)
+}
+
 func (s *Server) handlePost(w http.ResponseWriter, r *http.Request) {
 	// 获取文章 slug
 	slug := strings.TrimPrefix(r.URL.Path, "/posts/")
@@ -296,12 +474,70 @@ func (s *Server) handlePost(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
-func (s *Server) loadPosts() error {
-	contentDir := filepath.Join(s.projectDir, "content")
-	contentFS := os.DirFS(contentDir)
-	if err := s.posts.LoadFromFS(contentFS, "posts"); err != nil {
+// loadPosts 加载 content/posts 下的所有文章，全部加入后解析一次 wiki 链接
+func (s *Server) loadPosts(loader *post.Loader) error {
+	// 递归加载所有文章
+	postsDir := filepath.Join(s.projectDir, "content", "posts")
+	err := filepath.Walk(postsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// 跳过目录
+		if info.IsDir() {
+			return nil
+		}
+
+		// 只处理 .md 文件，_index.md 为分区信息
+		if !strings.HasSuffix(info.Name(), ".md") || info.Name() == post.IndexFile {
+			return nil
+		}
+
+		// 解析文章
+		parsePost, err := loader.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse post %s: %w", path, err)
+		}
+
+		// 如果没有验证信息或内容有变化，更新 contentHash
+		if parsePost.Verification == nil || parsePost.ContentChanged() {
+			if err := parsePost.UpdateContentHash(); err != nil {
+				return fmt.Errorf("failed to update content hash: %w", err)
+			}
+		}
+
+		// 如果没有设置 slug，使用相对路径作为 URL
+		if parsePost.Slug == "" {
+			relPath, err := filepath.Rel(postsDir, path)
+			if err != nil {
+				return fmt.Errorf("failed to get relative path: %w", err)
+			}
+			// 移除 .md 后缀
+			relPath = strings.TrimSuffix(relPath, ".md")
+			// 将路径分隔符转换为 URL 分隔符
+			parsePost.Slug = strings.ReplaceAll(relPath, string(filepath.Separator), "/")
+		}
+
+		// 分享图片在请求时生成
+		if s.config.OGImage.Enabled {
+			parsePost.OGImage = ogimage.Path(parsePost)
+		}
+
+		if err := s.posts.Add(parsePost); err != nil {
+			return fmt.Errorf("failed to add post %s: %w", path, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
 		return fmt.Errorf("failed to load posts: %w", err)
 	}
+
+	// 解析文章之间的 wiki 链接
+	if err := s.posts.ResolveLinks(); err != nil {
+		return fmt.Errorf("failed to resolve wiki links: %w", err)
+	}
 	return nil
 }
 
@@ -416,8 +652,8 @@ func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
-// handleTaxonomy 处理分类页面（标签和系列）
-func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy string) {
+// handleTaxonomy 处理分类项页面及其 RSS feed
+func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy config.Taxonomy) {
 	// 解析路径获取分类项和页码
 	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	if len(parts) < 2 {
@@ -432,6 +668,35 @@ func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy
 		return
 	}
 
+	// 获取文章列表
+	posts := s.posts.GetPostsByTerm(taxonomy.Name, term)
+
+	// 按日期排序
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date.After(posts[j].Date)
+	})
+
+	author, isAuthor := s.config.GetAuthor(term)
+	isAuthor = isAuthor && taxonomy.Name == "authors"
+
+	// 分类项的订阅源，作者的订阅源使用作者名称作为标题
+	if format, ok := feed.FormatByFile(parts[len(parts)-1]); ok && len(parts) == 3 {
+		if len(posts) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		title := term
+		if isAuthor && author.Name != "" {
+			title = author.Name
+		}
+		s.serveFeed(w, r, format, posts, feed.Options{
+			Title: fmt.Sprintf("%s - %s", title, s.config.Title),
+			Path:  "/" + taxonomy.Name + "/" + strings.ReplaceAll(term, " ", "-"),
+			Limit: feed.TermLimit(s.config.Feeds),
+		})
+		return
+	}
+
 	page := 1
 
 	// 检查是否有分页
@@ -441,22 +706,8 @@ func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy
 		}
 	}
 
-	// 获取文章列表
 	pageSize := 6
-	var posts []*post.Post
-	var totalPosts int
-
-	if taxonomy == "tags" {
-		posts = s.posts.GetPostsByTag(term)
-	} else {
-		posts = s.posts.GetPostsBySeries(term)
-	}
-	totalPosts = len(posts)
-
-	// 按日期排序
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].Date.After(posts[j].Date)
-	})
+	totalPosts := len(posts)
 
 	// 计算当前页的文章
 	start := (page - 1) * pageSize
@@ -466,20 +717,25 @@ func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy
 	}
 
 	// 创建分页数据
-	baseURL := fmt.Sprintf("/%s/%s", taxonomy, term)
+	baseURL := fmt.Sprintf("/%s/%s", taxonomy.Name, term)
 	pagination := template.NewPagination(page, pageSize, totalPosts, baseURL)
 
 	data := map[string]interface{}{
-		"Title":       fmt.Sprintf("%s: %s - 第%d页", taxonomy, term, page),
-		"Posts":       posts[start:end],
-		"Site":        s.config,
-		"Taxonomy":    taxonomy,
-		"Term":        term,
-		"SeriesStats": s.posts.GetSeriesStats(),
-		"TagsStats":   s.posts.GetTagsStats(),
-		"AllTags":     s.posts.GetAllTags(),
-		"Pagination":  pagination,
-		"TotalPosts":  totalPosts,
+		"Title":         fmt.Sprintf("%s: %s - 第%d页", taxonomy.Title, term, page),
+		"Posts":         posts[start:end],
+		"Site":          s.config,
+		"Taxonomy":      taxonomy.Name,
+		"TaxonomyTitle": taxonomy.Title,
+		"Term":          term,
+		"TermsStats":    s.posts.GetTermsStats(taxonomy.Name),
+		"SeriesStats":   s.posts.GetSeriesStats(),
+		"TagsStats":     s.posts.GetTagsStats(),
+		"AllTags":       s.posts.GetAllTags(),
+		"Pagination":    pagination,
+		"TotalPosts":    totalPosts,
+	}
+	if isAuthor {
+		data["Author"] = author
 	}
 
 	html, err := s.engine.RenderList(data)
@@ -491,3 +747,131 @@ func (s *Server) handleTaxonomy(w http.ResponseWriter, r *http.Request, taxonomy
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	fmt.Fprint(w, html)
 }
+
+// handleTerms 处理分类下所有分类项的列表页面
+func (s *Server) handleTerms(w http.ResponseWriter, r *http.Request, taxonomy config.Taxonomy) {
+	// 与构建一致，主题没有分类项列表模板时不提供该页面
+	if !s.engine.HasTemplate("terms", "") {
+		http.NotFound(w, r)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":         taxonomy.Title + " - " + s.config.Title,
+		"Taxonomy":      taxonomy.Name,
+		"TaxonomyTitle": taxonomy.Title,
+		"Terms":         s.posts.GetAllTerms(taxonomy.Name),
+		"TermsStats":    s.posts.GetTermsStats(taxonomy.Name),
+		"Site":          s.config,
+	}
+
+	html, err := s.engine.RenderTerms(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, html)
+}
+
+// serveFeed 输出文章列表的订阅源，未启用的格式返回 404
+func (s *Server) serveFeed(w http.ResponseWriter, r *http.Request, format feed.Format, posts []*post.Post, opts feed.Options) {
+	if !s.config.Feeds.Enabled(format.Name) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := feed.New(s.config, posts, opts).Write(&buf, format); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", format.MIME+"; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// handleArchive 处理归档页面：/archives/、/archives/2025/、/archives/2025/01/
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	// 与构建一致，主题没有归档模板时不提供归档页面
+	if !s.engine.HasTemplate("archive", "archives") {
+		http.NotFound(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/archives"), "/"), "/")
+	if parts[0] == "" {
+		parts = nil
+	}
+	if len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	var year, month int
+	if len(parts) > 0 {
+		y, err := strconv.Atoi(parts[0])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		year = y
+	}
+	if len(parts) > 1 {
+		m, err := strconv.Atoi(parts[1])
+		if err != nil || m < 1 || m > 12 {
+			http.NotFound(w, r)
+			return
+		}
+		month = m
+	}
+
+	// 根据路径确定归档范围
+	title := "归档"
+	var archives []*post.ArchiveYear
+	switch {
+	case month > 0:
+		m := s.posts.GetArchiveMonth(year, time.Month(month))
+		if m == nil {
+			http.NotFound(w, r)
+			return
+		}
+		title = fmt.Sprintf("%d 年 %d 月归档", year, month)
+		archives = []*post.ArchiveYear{{Year: year, Count: m.Count, Months: []*post.ArchiveMonth{m}}}
+	case year > 0:
+		y := s.posts.GetArchiveYear(year)
+		if y == nil {
+			http.NotFound(w, r)
+			return
+		}
+		title = fmt.Sprintf("%d 年归档", year)
+		archives = []*post.ArchiveYear{y}
+	default:
+		archives = s.posts.GetArchives()
+	}
+
+	total := 0
+	for _, y := range archives {
+		total += y.Count
+	}
+
+	data := map[string]interface{}{
+		"Title":      title + " - " + s.config.Title,
+		"Archives":   archives,
+		"Year":       year,
+		"Month":      month,
+		"YearStats":  s.posts.GetYearStats(),
+		"MonthStats": s.posts.GetMonthStats(),
+		"TotalPosts": total,
+		"Site":       s.config,
+	}
+
+	html, err := s.engine.RenderArchive(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, html)
+}
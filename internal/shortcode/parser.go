@@ -0,0 +1,375 @@
+package shortcode
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jiangjiax/stars/internal/mdutil"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// PageKey 用于在解析上下文中传入当前文章，供短代码模板通过 .Page 访问
+var PageKey = parser.NewContextKey()
+
+// KindBlock 独占一行（或多行成对）的短代码节点
+var KindBlock = ast.NewNodeKind("ShortcodeBlock")
+
+// KindInline 出现在段落中的短代码节点
+var KindInline = ast.NewNodeKind("ShortcodeInline")
+
+// Block 块级短代码
+type Block struct {
+	ast.BaseBlock
+	Call *Call
+	open bool // 是否还在等待结束标签
+}
+
+// Kind 实现 ast.Node
+func (n *Block) Kind() ast.NodeKind { return KindBlock }
+
+// IsRaw 成对短代码的内容不作为 markdown 解析
+func (n *Block) IsRaw() bool { return true }
+
+// Dump 实现 ast.Node
+func (n *Block) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Name": n.Call.Name}, nil)
+}
+
+// Inline 行内短代码
+type Inline struct {
+	ast.BaseInline
+	Call *Call
+}
+
+// Kind 实现 ast.Node
+func (n *Inline) Kind() ast.NodeKind { return KindInline }
+
+// Dump 实现 ast.Node
+func (n *Inline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Name": n.Call.Name}, nil)
+}
+
+// tag 解析得到的短代码标签
+type tag struct {
+	name       string
+	closing    bool // {{< /name >}}
+	selfClose  bool // {{< name />}}
+	positional []string
+	params     map[string]string
+	length     int // 标签占用的字节数
+}
+
+// call 根据标签创建调用信息
+func (t *tag) call(pc parser.Context) *Call {
+	return &Call{
+		Name:       t.name,
+		Positional: t.positional,
+		Params:     t.params,
+		Page:       pc.Get(PageKey),
+	}
+}
+
+// parseTag 解析以 {{< 开头的短代码标签
+func parseTag(src []byte) (*tag, bool) {
+	if !bytes.HasPrefix(src, []byte("{{<")) {
+		return nil, false
+	}
+
+	t := &tag{params: make(map[string]string)}
+	i := skipSpaces(src, 3)
+	if i < len(src) && src[i] == '/' {
+		t.closing = true
+		i = skipSpaces(src, i+1)
+	}
+
+	start := i
+	for i < len(src) && isNameChar(src[i]) {
+		i++
+	}
+	if i == start {
+		return nil, false
+	}
+	t.name = string(src[start:i])
+
+	for {
+		i = skipSpaces(src, i)
+		switch {
+		case i >= len(src):
+			return nil, false
+		case bytes.HasPrefix(src[i:], []byte(">}}")):
+			t.length = i + 3
+			return t, true
+		case bytes.HasPrefix(src[i:], []byte("/>}}")):
+			t.selfClose = true
+			t.length = i + 4
+			return t, true
+		}
+
+		if t.closing {
+			return nil, false
+		}
+
+		// 命名参数 key=value
+		keyEnd := i
+		for keyEnd < len(src) && isNameChar(src[keyEnd]) {
+			keyEnd++
+		}
+		if keyEnd > i && keyEnd < len(src) && src[keyEnd] == '=' {
+			value, n, ok := parseValue(src[keyEnd+1:])
+			if !ok {
+				return nil, false
+			}
+			t.params[string(src[i:keyEnd])] = value
+			i = keyEnd + 1 + n
+			continue
+		}
+
+		// 位置参数
+		value, n, ok := parseValue(src[i:])
+		if !ok {
+			return nil, false
+		}
+		t.positional = append(t.positional, value)
+		i += n
+	}
+}
+
+// parseValue 解析参数值，支持 "双引号"、`反引号` 和不带引号的写法
+func parseValue(src []byte) (string, int, bool) {
+	if len(src) == 0 {
+		return "", 0, false
+	}
+
+	switch src[0] {
+	case '"':
+		var sb strings.Builder
+		for i := 1; i < len(src); i++ {
+			switch src[i] {
+			case '\\':
+				if i+1 < len(src) {
+					i++
+					sb.WriteByte(src[i])
+				}
+			case '"':
+				return sb.String(), i + 1, true
+			case '\n':
+				return "", 0, false
+			default:
+				sb.WriteByte(src[i])
+			}
+		}
+		return "", 0, false
+	case '`':
+		end := bytes.IndexByte(src[1:], '`')
+		if end < 0 {
+			return "", 0, false
+		}
+		return string(src[1 : end+1]), end + 2, true
+	}
+
+	i := 0
+	for i < len(src) && !util.IsSpace(src[i]) &&
+		!bytes.HasPrefix(src[i:], []byte(">}}")) && !bytes.HasPrefix(src[i:], []byte("/>}}")) {
+		i++
+	}
+	if i == 0 {
+		return "", 0, false
+	}
+	return string(src[:i]), i, true
+}
+
+func skipSpaces(src []byte, i int) int {
+	for i < len(src) && util.IsSpace(src[i]) && src[i] != '\n' {
+		i++
+	}
+	return i
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || c == '_' || util.IsAlphaNumeric(c)
+}
+
+// closingPattern 匹配指定短代码的结束标签
+func closingPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`\{\{<\s*/\s*` + regexp.QuoteMeta(name) + `\s*>\}\}`)
+}
+
+// hasClosing 判断结束标签是否先于下一个同名短代码出现
+func hasClosing(src []byte, name string, closer *regexp.Regexp) bool {
+	loc := closer.FindIndex(src)
+	if loc == nil {
+		return false
+	}
+	opener := regexp.MustCompile(`\{\{<\s*` + regexp.QuoteMeta(name) + `[\s/>]`)
+	next := opener.FindIndex(src)
+	return next == nil || loc[0] < next[0]
+}
+
+// blockParser 解析独占一行的短代码
+type blockParser struct{}
+
+func (p *blockParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+func (p *blockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 {
+		return nil, parser.NoChildren
+	}
+
+	t, ok := parseTag(line[pos:])
+	if !ok || t.closing {
+		return nil, parser.NoChildren
+	}
+
+	node := &Block{Call: t.call(pc)}
+	rest := bytes.TrimSpace(line[pos+t.length:])
+	closer := closingPattern(t.name)
+
+	switch {
+	case t.selfClose:
+		if len(rest) > 0 {
+			return nil, parser.NoChildren
+		}
+	case len(rest) > 0:
+		// 同一行内的成对短代码
+		loc := closer.FindIndex(rest)
+		if loc == nil || len(bytes.TrimSpace(rest[loc[1]:])) > 0 {
+			return nil, parser.NoChildren
+		}
+		node.Call.Inner = string(rest[:loc[0]])
+	default:
+		// 在下一次同名调用之前出现结束标签时，作为跨行的成对短代码
+		node.open = hasClosing(reader.Source()[segment.Stop:], t.name, closer)
+	}
+
+	mdutil.SkipLine(reader)
+	return node, parser.NoChildren
+}
+
+func (p *blockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	n := node.(*Block)
+	if !n.open {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	if line == nil {
+		return parser.Close
+	}
+
+	if loc := closingPattern(n.Call.Name).FindIndex(line); loc != nil {
+		if len(bytes.TrimSpace(line[:loc[0]])) > 0 {
+			n.Lines().Append(text.NewSegment(segment.Start, segment.Start+loc[0]))
+		}
+		n.open = false
+		mdutil.SkipLine(reader)
+		return parser.Close
+	}
+
+	n.Lines().Append(segment)
+	mdutil.SkipLine(reader)
+	return parser.Continue | parser.NoChildren
+}
+
+func (p *blockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
+	n := node.(*Block)
+	if n.Lines().Len() == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		buf.Write(line.Value(reader.Source()))
+	}
+	n.Call.Inner = buf.String()
+}
+
+func (p *blockParser) CanInterruptParagraph() bool {
+	return false
+}
+
+func (p *blockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// inlineParser 解析段落中的短代码
+type inlineParser struct{}
+
+func (p *inlineParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+func (p *inlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	t, ok := parseTag(line)
+	if !ok || t.closing {
+		return nil
+	}
+
+	call := t.call(pc)
+	consumed := t.length
+	if !t.selfClose {
+		rest := line[t.length:]
+		if loc := closingPattern(t.name).FindIndex(rest); loc != nil {
+			call.Inner = string(rest[:loc[0]])
+			consumed += loc[1]
+		}
+	}
+
+	block.Advance(consumed)
+	return &Inline{Call: call}
+}
+
+// RegisterFuncs 实现 renderer.NodeRenderer
+func (r *Registry) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindBlock, r.renderNode)
+	reg.Register(KindInline, r.renderNode)
+}
+
+func (r *Registry) renderNode(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	var call *Call
+	switch n := node.(type) {
+	case *Block:
+		call = n.Call
+	case *Inline:
+		call = n.Call
+	}
+
+	html, err := r.Render(call)
+	if err != nil {
+		return ast.WalkStop, fmt.Errorf("shortcode %s: %w", call.Name, err)
+	}
+
+	_, _ = w.WriteString(html)
+	if node.Type() == ast.TypeBlock {
+		_ = w.WriteByte('\n')
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+// Extend 实现 goldmark.Extender，将短代码注册到 markdown 解析器
+func (r *Registry) Extend(m goldmark.Markdown) {
+	r.md = m
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&blockParser{}, 850)),
+		parser.WithInlineParsers(util.Prioritized(&inlineParser{}, 900)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(r, 100),
+	))
+}
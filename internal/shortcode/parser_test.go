@@ -0,0 +1,35 @@
+package shortcode
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestPairedShortcodeAtEOF(t *testing.T) {
+	dir := t.TempDir()
+	shortcodes := filepath.Join(dir, "layouts", "shortcodes")
+	if err := os.MkdirAll(shortcodes, 0755); err != nil {
+		t.Fatal(err)
+	}
+	note := `<div class="note">{{ .Inner }}</div>`
+	if err := os.WriteFile(filepath.Join(shortcodes, "note.html"), []byte(note), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 结束标签位于最后一行且没有换行符
+	source := "{{< note >}}\nhello\n{{< /note >}}"
+	var buf bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(New(dir, nil)))
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := "<div class=\"note\">hello\n</div>\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Convert(%q) = %q, want %q", source, got, want)
+	}
+}
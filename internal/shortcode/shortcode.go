@@ -0,0 +1,176 @@
+package shortcode
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/template/funcs"
+	"github.com/yuin/goldmark"
+)
+
+//go:embed templates/*.html
+var builtins embed.FS
+
+// 短代码别名
+var aliases = map[string]string{
+	"x":     "twitter",
+	"tweet": "twitter",
+}
+
+// 合法的短代码名称，防止通过名称访问模板目录之外的文件
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Call 表示文章中的一次短代码调用
+type Call struct {
+	Name       string            // 短代码名称
+	Positional []string          // 位置参数
+	Params     map[string]string // 命名参数
+	Inner      string            // 成对短代码包裹的内容
+	Page       interface{}       // 所在文章
+}
+
+// Context 短代码模板的渲染数据
+type Context struct {
+	*Call
+	Site *config.Config
+}
+
+// Get 按位置（int）或名称（string）获取参数
+func (c *Context) Get(key interface{}) string {
+	switch k := key.(type) {
+	case int:
+		if k >= 0 && k < len(c.Positional) {
+			return c.Positional[k]
+		}
+	case string:
+		return c.Params[k]
+	}
+	return ""
+}
+
+// IsNamedParams 是否使用了命名参数
+func (c *Context) IsNamedParams() bool {
+	return len(c.Params) > 0
+}
+
+// Registry 负责查找和渲染短代码模板
+//
+// 查找顺序：项目 layouts/shortcodes > 主题 layouts/shortcodes > 内置短代码
+type Registry struct {
+	site  *config.Config
+	dirs  []string
+	md    goldmark.Markdown
+	mu    sync.Mutex
+	cache map[string]*template.Template
+}
+
+// New 创建短代码注册表，projectDir 为空时只使用内置短代码
+func New(projectDir string, site *config.Config) *Registry {
+	r := &Registry{
+		site:  site,
+		cache: make(map[string]*template.Template),
+	}
+	if projectDir != "" {
+		r.dirs = append(r.dirs, filepath.Join(projectDir, "layouts", "shortcodes"))
+		if site != nil && site.Theme != "" {
+			r.dirs = append(r.dirs, filepath.Join(projectDir, "themes", site.Theme, "layouts", "shortcodes"))
+		}
+	}
+	return r
+}
+
+// Render 渲染一次短代码调用
+func (r *Registry) Render(call *Call) (string, error) {
+	tmpl, err := r.lookup(call.Name)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &Context{Call: call, Site: r.site}); err != nil {
+		return "", fmt.Errorf("failed to execute shortcode %s: %w", call.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// lookup 查找并缓存短代码模板
+func (r *Registry) lookup(name string) (*template.Template, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid shortcode name: %q", name)
+	}
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tmpl, ok := r.cache[name]; ok {
+		return tmpl, nil
+	}
+
+	content, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs.DefaultFuncs).Funcs(r.funcs()).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shortcode %s: %w", name, err)
+	}
+
+	r.cache[name] = tmpl
+	return tmpl, nil
+}
+
+// read 按优先级读取短代码模板内容
+func (r *Registry) read(name string) ([]byte, error) {
+	for _, dir := range r.dirs {
+		content, err := os.ReadFile(filepath.Join(dir, name+".html"))
+		if err == nil {
+			return content, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read shortcode %s: %w", name, err)
+		}
+	}
+
+	content, err := builtins.ReadFile("templates/" + name + ".html")
+	if err != nil {
+		return nil, fmt.Errorf("shortcode not found: %s", name)
+	}
+	return content, nil
+}
+
+// funcs 短代码模板专用的函数
+func (r *Registry) funcs() template.FuncMap {
+	return template.FuncMap{
+		"markdownify": r.markdownify,
+	}
+}
+
+// markdownify 使用同一个 markdown 解析器渲染内容，单个段落时去掉外层 <p>
+func (r *Registry) markdownify(s string) (template.HTML, error) {
+	if r.md == nil {
+		return template.HTML(template.HTMLEscapeString(s)), nil
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(s), &buf); err != nil {
+		return "", fmt.Errorf("markdownify: %w", err)
+	}
+
+	html := strings.TrimSpace(buf.String())
+	if strings.HasPrefix(html, "<p>") && strings.HasSuffix(html, "</p>") && strings.Count(html, "<p>") == 1 {
+		html = strings.TrimSuffix(strings.TrimPrefix(html, "<p>"), "</p>")
+	}
+	return template.HTML(html), nil
+}
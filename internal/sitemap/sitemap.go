@@ -1,116 +1,307 @@
+// Package sitemap 生成 sitemap.xml 和 robots.txt
 package sitemap
 
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
+	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jiangjiax/stars/internal/config"
 	"github.com/jiangjiax/stars/internal/post"
 )
 
+// MaxURLs 单个 sitemap 文件最多包含的地址数量，超过时拆分为多个文件并生成 sitemap 索引
+const MaxURLs = 50000
+
+const (
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	imageNS   = "http://www.google.com/schemas/sitemap-image/1.1"
+	xhtmlNS   = "http://www.w3.org/1999/xhtml"
+)
+
+// URL sitemap 中的一个页面
+type URL struct {
+	Path       string      // 站内路径，如 /posts/hello
+	LastMod    time.Time   // 最后修改时间，为零时不输出
+	Images     []string    // 页面中的图片地址
+	Alternates []Alternate // 其他语言版本，为空时不输出 hreflang
+}
+
+// Alternate 页面的一个语言版本
+type Alternate struct {
+	Lang string
+	Href string
+}
+
+// Sitemap 构建过程中收集的所有页面
 type Sitemap struct {
-	XMLName xml.Name `xml:"urlset"`
-	XMLNS   string   `xml:"xmlns,attr"`
-	URLs    []URL    `xml:"url"`
+	site *config.Config
+	urls map[string]*URL
 }
 
-type URL struct {
-	Loc        string  `xml:"loc"`
-	LastMod    string  `xml:"lastmod,omitempty"`
-	ChangeFreq string  `xml:"changefreq"`
-	Priority   float64 `xml:"priority"`
+// New 创建空的 sitemap
+func New(site *config.Config) *Sitemap {
+	return &Sitemap{
+		site: site,
+		urls: make(map[string]*URL),
+	}
 }
 
-// Generator sitemap 生成器
-type Generator struct {
-	config *config.Config
-	posts  []*post.Post
+// Add 添加一个页面，路径相同的页面只保留最后添加的一个
+func (s *Sitemap) Add(u URL) {
+	u.Path = "/" + strings.Trim(u.Path, "/")
+	s.urls[u.Path] = &u
 }
 
-// New 创建新的 sitemap 生成器
-func New(cfg *config.Config, posts []*post.Post) *Generator {
-	return &Generator{
-		config: cfg,
-		posts:  posts,
+// AddPost 添加文章或独立页面，robots 指令包含 noindex 的文章不会出现在 sitemap 中
+func (s *Sitemap) AddPost(p *post.Post) {
+	robots := p.Robots
+	if robots == "" {
+		robots = s.site.SEO.Robots
+	}
+	if p.Draft || strings.Contains(strings.ToLower(robots), "noindex") {
+		return
 	}
+
+	u := URL{
+		Path:    p.URL(),
+		LastMod: p.Lastmod,
+		Images:  images(string(p.Content)),
+	}
+	if p.OGImage != "" {
+		u.Images = append(u.Images, p.OGImage)
+	}
+	if len(p.Translations) > 0 {
+		u.Alternates = append(u.Alternates, Alternate{Lang: s.site.LanguageCode(), Href: p.URL()})
+		langs := make([]string, 0, len(p.Translations))
+		for lang := range p.Translations {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			u.Alternates = append(u.Alternates, Alternate{Lang: lang, Href: p.Translations[lang]})
+		}
+	}
+	s.Add(u)
 }
 
-// Generate 生成 sitemap.xml
-func (g *Generator) Generate(outputDir string) error {
-	sitemap := &Sitemap{
-		XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
-		URLs:  g.collectURLs(),
+// LastMod 一组文章中最晚的修改时间，列表页使用
+func LastMod(posts []*post.Post) time.Time {
+	var latest time.Time
+	for _, p := range posts {
+		if p.Lastmod.After(latest) {
+			latest = p.Lastmod
+		}
 	}
+	return latest
+}
 
-	// 确保输出目录存在
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+// imgSrc 匹配 HTML 中 img 标签的 src 属性
+var imgSrc = regexp.MustCompile(`<img\s[^>]*?src="([^"]+)"`)
+
+// images 提取文章内容中的图片地址，跳过内联的 data URI，构建时改写成的相对路径还原为站内路径
+func images(content string) []string {
+	var srcs []string
+	seen := make(map[string]bool)
+	for _, m := range imgSrc.FindAllStringSubmatch(content, -1) {
+		src := m[1]
+		if strings.HasPrefix(src, "data:") {
+			continue
+		}
+		if !strings.Contains(src, "://") && !strings.HasPrefix(src, "/") {
+			for strings.HasPrefix(src, "../") || strings.HasPrefix(src, "./") {
+				src = strings.TrimPrefix(strings.TrimPrefix(src, "../"), "./")
+			}
+			src = "/" + src
+		}
+		if seen[src] {
+			continue
+		}
+		seen[src] = true
+		srcs = append(srcs, src)
 	}
+	return srcs
+}
 
-	// 创建文件
-	outputPath := filepath.Join(outputDir, "sitemap.xml")
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create sitemap file: %w", err)
+type urlset struct {
+	XMLName    xml.Name `xml:"urlset"`
+	XMLNS      string   `xml:"xmlns,attr"`
+	XMLNSImage string   `xml:"xmlns:image,attr"`
+	XMLNSXHTML string   `xml:"xmlns:xhtml,attr"`
+	URLs       []xmlURL `xml:"url"`
+}
+
+type xmlURL struct {
+	Loc     string     `xml:"loc"`
+	LastMod string     `xml:"lastmod,omitempty"`
+	Links   []xmlLink  `xml:"xhtml:link"`
+	Images  []xmlImage `xml:"image:image"`
+}
+
+type xmlLink struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type xmlImage struct {
+	Loc string `xml:"image:loc"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []xmlSitemap `xml:"sitemap"`
+}
+
+type xmlSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Save 将 sitemap 写入 dir，页面超过 MaxURLs 时写入 sitemap-1.xml、sitemap-2.xml 等文件，
+// sitemap.xml 则作为索引。baseURL 不是完整地址时输出警告，此时生成的地址是相对路径，搜索引擎不会接受
+func (s *Sitemap) Save(dir string) error {
+	if !isAbs(s.site.BaseURL) {
+		log.Printf("Warning: baseURL %q is not an absolute URL (e.g. https://example.com), sitemap.xml and robots.txt will contain relative URLs", s.site.BaseURL)
 	}
-	defer file.Close()
 
-	// 写入 XML 头
-	if _, err := file.Write([]byte(xml.Header)); err != nil {
-		return fmt.Errorf("failed to write XML header: %w", err)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// 创建编码器并设置缩进
-	encoder := xml.NewEncoder(file)
-	encoder.Indent("", "  ")
+	urls := s.sorted()
+	if len(urls) <= MaxURLs {
+		return writeXML(filepath.Join(dir, "sitemap.xml"), s.urlset(urls))
+	}
+
+	index := sitemapIndex{XMLNS: sitemapNS}
+	for i := 0; i*MaxURLs < len(urls); i++ {
+		end := (i + 1) * MaxURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		chunk := urls[i*MaxURLs : end]
+		name := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if err := writeXML(filepath.Join(dir, name), s.urlset(chunk)); err != nil {
+			return err
+		}
+
+		entry := xmlSitemap{Loc: s.absURL("/" + name)}
+		if t := latest(chunk); !t.IsZero() {
+			entry.LastMod = t.Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, entry)
+	}
+	return writeXML(filepath.Join(dir, "sitemap.xml"), index)
+}
 
-	// 编码并写入文件
-	if err := encoder.Encode(sitemap); err != nil {
-		return fmt.Errorf("failed to encode sitemap: %w", err)
+// SaveRobots 在 dir 中生成 robots.txt，允许抓取 seo.disallow 以外的所有页面并指向 sitemap
+func (s *Sitemap) SaveRobots(dir string) error {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if len(s.site.SEO.Disallow) == 0 {
+		b.WriteString("Allow: /\n")
 	}
+	for _, path := range s.site.SEO.Disallow {
+		fmt.Fprintf(&b, "Disallow: %s\n", path)
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s\n", s.absURL("/sitemap.xml"))
 
+	if err := os.WriteFile(filepath.Join(dir, "robots.txt"), []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write robots.txt: %w", err)
+	}
 	return nil
 }
 
-// collectURLs 收集所有 URL
-func (g *Generator) collectURLs() []URL {
-	var urls []URL
-	baseURL := g.config.BaseURL
-
-	// 添加首页
-	urls = append(urls, URL{
-		Loc:        baseURL,
-		ChangeFreq: "daily",
-		Priority:   1.0,
+// sorted 按路径排序的页面，首页在最前面
+func (s *Sitemap) sorted() []*URL {
+	urls := make([]*URL, 0, len(s.urls))
+	for _, u := range s.urls {
+		urls = append(urls, u)
+	}
+	sort.Slice(urls, func(i, j int) bool {
+		return urls[i].Path < urls[j].Path
 	})
+	return urls
+}
 
-	// 添加文章列表页
-	urls = append(urls, URL{
-		Loc:        baseURL + "/posts",
-		ChangeFreq: "daily",
-		Priority:   0.9,
-	})
+func (s *Sitemap) urlset(urls []*URL) urlset {
+	set := urlset{XMLNS: sitemapNS, XMLNSImage: imageNS, XMLNSXHTML: xhtmlNS}
+	for _, u := range urls {
+		entry := xmlURL{Loc: s.absURL(u.Path)}
+		if !u.LastMod.IsZero() {
+			entry.LastMod = u.LastMod.Format(time.RFC3339)
+		}
+		for _, a := range u.Alternates {
+			entry.Links = append(entry.Links, xmlLink{Rel: "alternate", Hreflang: a.Lang, Href: s.absURL(a.Href)})
+		}
+		for _, img := range u.Images {
+			entry.Images = append(entry.Images, xmlImage{Loc: s.absURL(img)})
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+	return set
+}
 
-	// 添加标签页
-	urls = append(urls, URL{
-		Loc:        baseURL + "/tags",
-		ChangeFreq: "weekly",
-		Priority:   0.8,
-	})
+// absURL 完整且经过转义的地址，sitemap 要求地址中的非 ASCII 字符使用百分号编码
+func (s *Sitemap) absURL(p string) string {
+	if u, err := url.Parse(p); err == nil && !u.IsAbs() {
+		p = u.EscapedPath()
+		if u.RawQuery != "" {
+			p += "?" + u.RawQuery
+		}
+	}
+	return config.AbsURL(s.site.BaseURL, p)
+}
+
+// isAbs baseURL 是否为带协议和域名的完整地址
+func isAbs(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
 
-	// 添加所有文章页面
-	for _, p := range g.posts {
-		if !p.Draft {
-			urls = append(urls, URL{
-				Loc:        baseURL + "/posts/" + p.Slug,
-				LastMod:    p.Date.Format("2006-01-02"),
-				ChangeFreq: "monthly",
-				Priority:   0.7,
-			})
+// latest 一组页面中最晚的修改时间
+func latest(urls []*URL) time.Time {
+	var t time.Time
+	for _, u := range urls {
+		if u.LastMod.After(t) {
+			t = u.LastMod
 		}
 	}
+	return t
+}
 
-	return urls
-} 
\ No newline at end of file
+func writeXML(path string, v interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	if err := encode(file, v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func encode(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
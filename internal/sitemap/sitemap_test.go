@@ -0,0 +1,106 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+func TestSave(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastmod := func(i int) string {
+		return base.Add(time.Duration(i) * time.Minute).Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		name     string
+		urls     int // 包括首页在内的页面数量
+		sitemaps []xmlSitemap // 为空时 sitemap.xml 不是索引
+	}{
+		{"single", 3, nil},
+		{"full", MaxURLs, nil},
+		{"split", MaxURLs + 1, []xmlSitemap{
+			{Loc: "https://example.com/sitemap-1.xml", LastMod: lastmod(MaxURLs - 2)},
+			{Loc: "https://example.com/sitemap-2.xml", LastMod: lastmod(MaxURLs - 1)},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Config{BaseURL: "https://example.com"})
+			for i := 0; i < tt.urls-1; i++ {
+				s.Add(URL{Path: fmt.Sprintf("/posts/%06d", i), LastMod: base.Add(time.Duration(i) * time.Minute)})
+			}
+			s.Add(URL{Path: "/"}) // 没有修改时间的页面不输出 lastmod
+
+			dir := t.TempDir()
+			if err := s.Save(dir); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.sitemaps == nil {
+				var set struct {
+					URLs []xmlURL `xml:"url"`
+				}
+				readXML(t, filepath.Join(dir, "sitemap.xml"), &set)
+				if len(set.URLs) != tt.urls {
+					t.Fatalf("got %d urls, want %d", len(set.URLs), tt.urls)
+				}
+				if u := set.URLs[0]; u.Loc != "https://example.com/" || u.LastMod != "" {
+					t.Errorf("first url = %+v, want the home page without lastmod", u)
+				}
+				if u := set.URLs[1]; u.Loc != "https://example.com/posts/000000" || u.LastMod != lastmod(0) {
+					t.Errorf("second url = %+v, want /posts/000000 with lastmod %s", u, lastmod(0))
+				}
+				if _, err := os.Stat(filepath.Join(dir, "sitemap-1.xml")); !os.IsNotExist(err) {
+					t.Errorf("sitemap-1.xml should not be written, stat error = %v", err)
+				}
+				return
+			}
+
+			var index struct {
+				XMLName  xml.Name
+				Sitemaps []xmlSitemap `xml:"sitemap"`
+			}
+			readXML(t, filepath.Join(dir, "sitemap.xml"), &index)
+			if index.XMLName.Local != "sitemapindex" {
+				t.Fatalf("sitemap.xml root = %s, want sitemapindex", index.XMLName.Local)
+			}
+			if len(index.Sitemaps) != len(tt.sitemaps) {
+				t.Fatalf("got %d sitemaps, want %d", len(index.Sitemaps), len(tt.sitemaps))
+			}
+			total := 0
+			for i, want := range tt.sitemaps {
+				if index.Sitemaps[i] != want {
+					t.Errorf("sitemap %d = %+v, want %+v", i, index.Sitemaps[i], want)
+				}
+				var set struct {
+					URLs []xmlURL `xml:"url"`
+				}
+				readXML(t, filepath.Join(dir, fmt.Sprintf("sitemap-%d.xml", i+1)), &set)
+				if len(set.URLs) > MaxURLs {
+					t.Errorf("sitemap-%d.xml has %d urls, more than %d", i+1, len(set.URLs), MaxURLs)
+				}
+				total += len(set.URLs)
+			}
+			if total != tt.urls {
+				t.Errorf("sitemaps contain %d urls, want %d", total, tt.urls)
+			}
+		})
+	}
+}
+
+func readXML(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to parse %s: %v", filepath.Base(path), err)
+	}
+}
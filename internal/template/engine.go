@@ -9,6 +9,7 @@ import (
 
 	"github.com/jiangjiax/stars/internal/asset"
 	"github.com/jiangjiax/stars/internal/config"
+	_ "github.com/jiangjiax/stars/internal/seo" // 注册 seo 模板函数
 	"github.com/jiangjiax/stars/internal/template/funcs"
 )
 
@@ -142,15 +143,19 @@ func (e *Engine) processTemplateData(data interface{}, kind, section string) (in
 	}
 
 	// 设置页面类型标志
+	m["Kind"] = kind
 	m["IsPost"] = (kind == "single" && section == "posts")
+	m["IsPage"] = kind == "page"
 
 	if _, exists := m["Site"]; !exists {
 		m["Site"] = e.config
 	}
 
 	if e.buildMode {
-		depth := e.calculatePathDepth(kind, section)
-		prefix := e.buildPathPrefix(depth)
+		// 相对路径的层数取决于页面的输出目录，如 /archives/2025/01 为 3，
+		// 同一种页面（分页列表、多级页面）的层数也可能不同
+		url, _ := m["URL"].(string)
+		prefix := e.buildPathPrefix(urlDepth(url))
 		e.setStaticPaths(m, prefix)
 		e.processImagePaths(m, prefix)
 	} else {
@@ -162,14 +167,13 @@ func (e *Engine) processTemplateData(data interface{}, kind, section string) (in
 	return m, nil
 }
 
-// 计算路径深度
-func (e *Engine) calculatePathDepth(kind, section string) int {
+// urlDepth 页面输出目录相对站点根目录的层数，首页为 0
+func urlDepth(url string) int {
 	depth := 0
-	if section != "" {
-		depth++
-	}
-	if kind != "index" {
-		depth++
+	for _, part := range strings.Split(url, "/") {
+		if part != "" {
+			depth++
+		}
 	}
 	return depth
 }
@@ -202,15 +206,26 @@ func (e *Engine) processImagePaths(m map[string]interface{}, prefix string) {
 	}
 }
 
+// kindAliases 分区列表页和分区内容页在分区目录下也可以使用 list.html 和 single.html
+var kindAliases = map[string]string{
+	"section": "list",
+	"page":    "single",
+}
+
 // lookupTemplate 按照优先级查找模板
 func (e *Engine) lookupTemplate(kind, section string) string {
 	// 模板查找顺序
 	lookupOrder := []string{
-		filepath.Join(section, kind+".html"),        // posts/single.html
+		filepath.Join(section, kind+".html"), // posts/single.html
+	}
+	if alias, ok := kindAliases[kind]; ok && section != "" {
+		lookupOrder = append(lookupOrder, filepath.Join(section, alias+".html")) // notes/list.html
+	}
+	lookupOrder = append(lookupOrder,
 		filepath.Join("_default", kind+".html"),     // _default/single.html
 		filepath.Join(section, "_"+kind+".html"),    // posts/_single.html
 		filepath.Join("_default", "_"+kind+".html"), // _default/_single.html
-	}
+	)
 
 	// 检查文件是否存在
 	for _, name := range lookupOrder {
@@ -227,6 +242,12 @@ func (e *Engine) lookupTemplate(kind, section string) string {
 	return filepath.Join("_default", kind+".html")
 }
 
+// HasTemplate 主题中是否有 kind 对应的页面模板，归档页和分类项列表页等新增页面需要主题提供模板
+func (e *Engine) HasTemplate(kind, section string) bool {
+	_, err := os.Stat(filepath.Join(e.layoutDir, e.lookupTemplate(kind, section)))
+	return err == nil
+}
+
 // RenderHome 渲染首页
 func (e *Engine) RenderHome(data map[string]interface{}) (string, error) {
 	return e.render("index", "", data)
@@ -246,3 +267,23 @@ func (e *Engine) RenderSingle(data map[string]interface{}) (string, error) {
 func (e *Engine) RenderTags(data map[string]interface{}) (string, error) {
 	return e.render("tags", "", data)
 }
+
+// RenderTerms 渲染分类项列表页面
+func (e *Engine) RenderTerms(data map[string]interface{}) (string, error) {
+	return e.render("terms", "", data)
+}
+
+// RenderSection 渲染分区列表页，依次查找 分区/section.html、分区/list.html 和 _default/section.html
+func (e *Engine) RenderSection(section string, data map[string]interface{}) (string, error) {
+	return e.render("section", section, data)
+}
+
+// RenderPage 渲染分区内容页和独立页面，依次查找 分区/page.html、分区/single.html 和 _default/page.html
+func (e *Engine) RenderPage(section string, data map[string]interface{}) (string, error) {
+	return e.render("page", section, data)
+}
+
+// RenderArchive 渲染归档页面
+func (e *Engine) RenderArchive(data map[string]interface{}) (string, error) {
+	return e.render("archive", "archives", data)
+}
@@ -0,0 +1,37 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/jiangjiax/stars/internal/config"
+)
+
+func TestStaticPathDepth(t *testing.T) {
+	tests := []struct {
+		kind    string
+		section string
+		url     string
+		want    string
+	}{
+		{"index", "", "/", "./static"},
+		{"archive", "archives", "/archives", "../static"},
+		{"archive", "archives", "/archives/2025", "../../static"},
+		{"archive", "archives", "/archives/2025/01", "../../../static"},
+		{"list", "notes", "/notes", "../static"},
+		{"list", "", "/posts/page/2", "../../../static"},
+		{"single", "notes", "/notes/a/b", "../../../static"},
+		{"page", "", "/about", "../static"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			e := &Engine{config: &config.Config{}, buildMode: true}
+			data, err := e.processTemplateData(map[string]interface{}{"URL": tt.url}, tt.kind, tt.section)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := data.(map[string]interface{})["StaticPath"]; got != tt.want {
+				t.Errorf("StaticPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
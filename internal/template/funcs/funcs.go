@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"log"
 	"net/url"
 	"path/filepath"
 	"reflect"
 	"sort"
 	"github.com/jiangjiax/stars/internal/asset"
+	"github.com/jiangjiax/stars/internal/config"
+	"github.com/jiangjiax/stars/internal/imaging"
 	"strings"
 	"time"
 )
@@ -24,6 +27,8 @@ var (
 		"trimPrefix": strings.TrimPrefix,
 		"jsonify":    jsonify,
 		"AssetPath":  getAssetPath,
+		"Integrity":  getIntegrity,
+		"HasAsset":   hasAsset,
 		"hasPrefix":  strings.HasPrefix,
 		"hasSuffix":  strings.HasSuffix,
 		"contains":   strings.Contains,
@@ -90,6 +95,13 @@ var (
 		"basename": filepath.Base,
 		"dirname":  filepath.Dir,
 		"ext":      filepath.Ext,
+		"absURL":   config.AbsURL,
+	}
+
+	// 图片处理函数
+	imageFuncs = template.FuncMap{
+		"imageResize": imageResize,
+		"srcset":      srcset,
 	}
 
 	// 合并所有函数映射
@@ -103,9 +115,11 @@ var (
 		utilFuncs,
 		mathFuncs,
 		pathFuncs,
+		imageFuncs,
 	)
 
-	assetPipeline *asset.Pipeline
+	assetPipeline  *asset.Pipeline
+	imageProcessor *imaging.Processor
 )
 
 // 字符串处理函数
@@ -456,6 +470,61 @@ func getAssetPath(name string) string {
 	return assetPipeline.GetAssetPath(name)
 }
 
+// 设置图片处理器实例
+func SetImageProcessor(p *imaging.Processor) {
+	imageProcessor = p
+}
+
+// imageResize 生成指定宽度的图片，可选第三个参数指定格式（如 webp）。
+// 未启用图片处理或无法处理时返回原地址
+func imageResize(src string, width int, format ...string) string {
+	if imageProcessor == nil {
+		return src
+	}
+	f := ""
+	if len(format) > 0 {
+		f = format[0]
+	}
+	img, err := imageProcessor.Resize(src, width, f)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return src
+	}
+	return img.URL
+}
+
+// srcset 生成图片各个宽度的 srcset 属性值，未启用图片处理或无法处理时返回空字符串
+func srcset(src string) string {
+	if imageProcessor == nil {
+		return ""
+	}
+	set, err := imageProcessor.Process(src)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return ""
+	}
+	if set == nil {
+		return ""
+	}
+	return set.Fallback.Srcset()
+}
+
+// 资源是否构建成功，打包失败时模板可以不输出对应的标签
+func hasAsset(name string) bool {
+	if assetPipeline == nil {
+		return false
+	}
+	return assetPipeline.HasAsset(name)
+}
+
+// 获取资源文件的 SRI 哈希
+func getIntegrity(name string) string {
+	if assetPipeline == nil {
+		return ""
+	}
+	return assetPipeline.GetIntegrity(name)
+}
+
 // 数学运算函数
 func div(a, b int) float64 {
 	if b == 0 {
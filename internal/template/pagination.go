@@ -1,5 +1,7 @@
 package template
 
+import "fmt"
+
 type Pagination struct {
 	CurrentPage int
 	PageSize    int
@@ -34,3 +36,11 @@ func NewPagination(currentPage, pageSize, totalPosts int, baseURL string) *Pagin
 
 	return p
 }
+
+// URL 当前页的地址，第一页为 BaseURL，其余为 BaseURL/page/N
+func (p *Pagination) URL() string {
+	if p.CurrentPage <= 1 {
+		return p.BaseURL
+	}
+	return fmt.Sprintf("%s/page/%d", p.BaseURL, p.CurrentPage)
+}
@@ -26,6 +26,14 @@ type Theme struct {
 	Homepage    string   `yaml:"homepage"`
 	License     string   `yaml:"license"`
 	Tags        []string `yaml:"tags"`
+	Assets      Assets   `yaml:"assets,omitempty"`
+}
+
+// Assets 主题资源构建配置，路径相对于主题的 static 目录
+type Assets struct {
+	NPM bool     `yaml:"npm,omitempty"` // 构建前运行主题的 npm run build:css 和 build:js
+	CSS []string `yaml:"css,omitempty"` // CSS 入口，默认为 css/styles.built.css 或 css/styles.css
+	JS  []string `yaml:"js,omitempty"`  // JS 入口，默认为 js 目录下的所有 .js 文件
 }
 
 // New 创建主题管理器
@@ -140,7 +148,12 @@ func (m *Manager) Use(name string) error {
 
 // loadTheme 加载主题配置
 func (m *Manager) loadTheme(name string) (*Theme, error) {
-	configPath := filepath.Join(m.themesDir, name, "theme.yaml")
+	return Load(filepath.Join(m.themesDir, name))
+}
+
+// Load 加载主题目录下的 theme.yaml
+func Load(themeDir string) (*Theme, error) {
+	configPath := filepath.Join(themeDir, "theme.yaml")
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -0,0 +1,153 @@
+package wikilink
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ResolverKey 用于在解析上下文中传入 Resolver，未设置时所有链接都视为未解析
+var ResolverKey = parser.NewContextKey()
+
+// Resolver 根据链接目标（slug 或标题）查找文章，返回链接地址和文章标题
+type Resolver func(target string) (dest string, title string, ok bool)
+
+// KindWikiLink wiki 链接节点
+var KindWikiLink = ast.NewNodeKind("WikiLink")
+
+// Link [[目标]] 或 [[目标|文字]] 形式的链接，目标可以带 #锚点
+type Link struct {
+	ast.BaseInline
+	Target      string // 链接目标，不含锚点
+	Fragment    string // 锚点，不含 #
+	Label       string // 链接文字，未指定时使用文章标题
+	Destination string // 解析得到的链接地址，未解析时为空
+}
+
+// Kind 实现 ast.Node
+func (n *Link) Kind() ast.NodeKind { return KindWikiLink }
+
+// Dump 实现 ast.Node
+func (n *Link) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Target":      n.Target,
+		"Destination": n.Destination,
+	}, nil)
+}
+
+// Resolved 链接是否找到了对应的文章
+func (n *Link) Resolved() bool {
+	return n.Destination != ""
+}
+
+var (
+	openMark  = []byte("[[")
+	closeMark = []byte("]]")
+)
+
+// linkParser 解析段落中的 [[...]]
+type linkParser struct{}
+
+func (p *linkParser) Trigger() []byte {
+	return []byte{'['}
+}
+
+func (p *linkParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	if !bytes.HasPrefix(line, openMark) {
+		return nil
+	}
+	end := bytes.Index(line[2:], closeMark)
+	if end <= 0 {
+		return nil
+	}
+	inner := line[2 : end+2]
+	if bytes.ContainsAny(inner, "[]") {
+		return nil
+	}
+
+	target, label := inner, []byte(nil)
+	if i := bytes.IndexByte(inner, '|'); i >= 0 {
+		target, label = inner[:i], bytes.TrimSpace(inner[i+1:])
+	}
+	var fragment []byte
+	if i := bytes.IndexByte(target, '#'); i >= 0 {
+		target, fragment = target[:i], target[i+1:]
+	}
+	target = bytes.TrimSpace(target)
+	if len(target) == 0 {
+		return nil
+	}
+
+	node := &Link{
+		Target:   string(target),
+		Fragment: string(bytes.TrimSpace(fragment)),
+		Label:    string(label),
+	}
+	if resolve, ok := pc.Get(ResolverKey).(Resolver); ok && resolve != nil {
+		if dest, title, ok := resolve(node.Target); ok {
+			node.Destination = dest
+			if node.Fragment != "" {
+				node.Destination += "#" + node.Fragment
+			}
+			if node.Label == "" {
+				node.Label = title
+			}
+		}
+	}
+	if node.Label == "" {
+		node.Label = node.Target
+	}
+
+	block.Advance(end + 4)
+	return node
+}
+
+// Renderer 输出 wiki 链接，未解析的链接输出为带 wikilink-broken 类的 <span>
+type Renderer struct{}
+
+// RegisterFuncs 实现 renderer.NodeRenderer
+func (r *Renderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindWikiLink, r.renderLink)
+}
+
+func (r *Renderer) renderLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*Link)
+	if n.Resolved() {
+		_, _ = w.WriteString(`<a class="wikilink" href="`)
+		_, _ = w.Write(util.EscapeHTML(util.URLEscape([]byte(n.Destination), true)))
+		_, _ = w.WriteString(`">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(n.Label)))
+		_, _ = w.WriteString("</a>")
+	} else {
+		_, _ = w.WriteString(`<span class="wikilink wikilink-broken" title="`)
+		_, _ = w.Write(util.EscapeHTML([]byte(n.Target)))
+		_, _ = w.WriteString(`">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(n.Label)))
+		_, _ = w.WriteString("</span>")
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+type extension struct{}
+
+// Extension wiki 链接扩展，支持 [[slug]]、[[标题|文字]] 和 [[slug#锚点]]
+var Extension = &extension{}
+
+// Extend 实现 goldmark.Extender
+func (e *extension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithInlineParsers(util.Prioritized(&linkParser{}, 199)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&Renderer{}, 500),
+	))
+}